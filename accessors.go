@@ -0,0 +1,191 @@
+package publiccode
+
+// The types returned by the accessors of PublicCode, the same for all the
+// versions of publiccode.yml.
+
+// Description is the description of the software in a language.
+type Description struct {
+	LocalisedName    *string   `json:"localisedName,omitempty"`
+	ShortDescription string    `json:"shortDescription"`
+	LongDescription  string    `json:"longDescription,omitempty"`
+	Documentation    *URL      `json:"documentation,omitempty"`
+	APIDocumentation *URL      `json:"apiDocumentation,omitempty"`
+	Features         *[]string `json:"features,omitempty"`
+	Screenshots      []string  `json:"screenshots,omitempty"`
+	Videos           []*URL    `json:"videos,omitempty"`
+	Awards           []string  `json:"awards,omitempty"`
+}
+
+// Organisation is a real world organisation.
+type Organisation struct {
+	Name *string `json:"name,omitempty"`
+	URI  string  `json:"uri"`
+}
+
+// Maintenance describes how the software is maintained.
+type Maintenance struct {
+	Type        string       `json:"type"`
+	Contractors []Contractor `json:"contractors,omitempty"`
+	Contacts    []Contact    `json:"contacts,omitempty"`
+}
+
+// Contractor is an entity contracted for maintaining the software.
+type Contractor struct {
+	Name    string  `json:"name"`
+	Email   *string `json:"email,omitempty"`
+	Website *URL    `json:"website,omitempty"`
+	Until   string  `json:"until"`
+}
+
+// Contact is a contact info maintaining the software.
+type Contact struct {
+	Name        string  `json:"name"`
+	Email       *string `json:"email,omitempty"`
+	Affiliation *string `json:"affiliation,omitempty"`
+	Phone       *string `json:"phone,omitempty"`
+}
+
+// GetName returns the name of the software.
+func (p PublicCodeV0) GetName() string {
+	return p.Name
+}
+
+// GetLicense returns the SPDX license expression of the software (eg.
+// "AGPL-3.0-or-later"). See GetLicenseExpression to parse it.
+func (p PublicCodeV0) GetLicense() string {
+	return p.Legal.License
+}
+
+// GetDescription returns the description in lang (eg. "en"), and false if
+// there's none.
+func (p PublicCodeV0) GetDescription(lang string) (Description, bool) {
+	desc, ok := p.Description[lang]
+	if !ok {
+		return Description{}, false
+	}
+
+	return Description{
+		LocalisedName:    desc.LocalisedName,
+		ShortDescription: desc.ShortDescription,
+		LongDescription:  desc.LongDescription,
+		Documentation:    desc.Documentation,
+		APIDocumentation: desc.APIDocumentation,
+		Features:         desc.Features,
+		Screenshots:      desc.Screenshots,
+		Videos:           desc.Videos,
+		Awards:           desc.Awards,
+	}, true
+}
+
+// GetLanguages returns the languages the software is available in.
+func (p PublicCodeV0) GetLanguages() []string {
+	return p.Localisation.AvailableLanguages
+}
+
+// GetOrganisation returns the organisation publishing the software, or nil.
+func (p PublicCodeV0) GetOrganisation() *Organisation {
+	if p.Organisation == nil {
+		return nil
+	}
+
+	return (*Organisation)(p.Organisation)
+}
+
+// GetMaintenance returns how the software is maintained, with its contractors
+// and contacts.
+func (p PublicCodeV0) GetMaintenance() Maintenance {
+	return Maintenance{
+		Type:        p.Maintenance.Type,
+		Contractors: convertList(p.Maintenance.Contractors, func(c ContractorV0) Contractor { return Contractor(c) }),
+		Contacts:    convertList(p.Maintenance.Contacts, func(c ContactV0) Contact { return Contact(c) }),
+	}
+}
+
+// GetCategories returns the categories of the software, or nil.
+func (p PublicCodeV0) GetCategories() []string {
+	return derefOr(p.Categories, nil)
+}
+
+// GetLogo returns the path or URL of the logo, or "" if there's none.
+func (p PublicCodeV0) GetLogo() string {
+	return derefOr(p.Logo, "")
+}
+
+// GetDevelopmentStatus returns the development status of the software (eg.
+// "stable").
+func (p PublicCodeV0) GetDevelopmentStatus() string {
+	return p.DevelopmentStatus
+}
+
+// GetName returns the name of the software.
+func (p PublicCodeV1) GetName() string {
+	return p.Name
+}
+
+// GetLicense returns the SPDX license expression of the software (eg.
+// "AGPL-3.0-or-later"). See GetLicenseExpression to parse it.
+func (p PublicCodeV1) GetLicense() string {
+	return p.Legal.License
+}
+
+// GetDescription returns the description in lang (eg. "en"), and false if
+// there's none.
+func (p PublicCodeV1) GetDescription(lang string) (Description, bool) {
+	desc, ok := p.Description[lang]
+
+	return Description(desc), ok
+}
+
+// GetLanguages returns the languages the software is available in.
+func (p PublicCodeV1) GetLanguages() []string {
+	return p.Localisation.AvailableLanguages
+}
+
+// GetOrganisation returns the organisation publishing the software, or nil.
+func (p PublicCodeV1) GetOrganisation() *Organisation {
+	if p.Organisation == nil {
+		return nil
+	}
+
+	return (*Organisation)(p.Organisation)
+}
+
+// GetMaintenance returns how the software is maintained, with its contractors
+// and contacts.
+func (p PublicCodeV1) GetMaintenance() Maintenance {
+	return Maintenance{
+		Type:        p.Maintenance.Type,
+		Contractors: convertList(p.Maintenance.Contractors, func(c ContractorV1) Contractor { return Contractor(c) }),
+		Contacts:    convertList(p.Maintenance.Contacts, func(c ContactV1) Contact { return Contact(c) }),
+	}
+}
+
+// GetCategories returns the categories of the software, or nil.
+func (p PublicCodeV1) GetCategories() []string {
+	return derefOr(p.Categories, nil)
+}
+
+// GetLogo returns the path or URL of the logo, or "" if there's none.
+func (p PublicCodeV1) GetLogo() string {
+	return derefOr(p.Logo, "")
+}
+
+// GetDevelopmentStatus returns the development status of the software (eg.
+// "stable").
+func (p PublicCodeV1) GetDevelopmentStatus() string {
+	return p.DevelopmentStatus
+}
+
+// convertList returns the items of list, if any, converted with convert.
+func convertList[From any, To any](list *[]From, convert func(From) To) []To {
+	if list == nil {
+		return nil
+	}
+
+	out := make([]To, 0, len(*list))
+	for _, item := range *list {
+		out = append(out, convert(item))
+	}
+
+	return out
+}
@@ -0,0 +1,78 @@
+package publiccode
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAccessors(t *testing.T) {
+	p, err := NewParser(ParserConfig{DisableExternalChecks: true, EnableDraftVersions: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v0, err := p.Parse("testdata/v0/valid/valid.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := v0.(PublicCodeV0); !ok {
+		t.Fatalf("unexpected type %T", v0)
+	}
+
+	if v0.GetName() != "Medusa" || v0.GetLicense() != "AGPL-3.0-or-later" || v0.GetDevelopmentStatus() != "development" {
+		t.Errorf("unexpected values: %q, %q, %q", v0.GetName(), v0.GetLicense(), v0.GetDevelopmentStatus())
+	}
+
+	if languages := v0.GetLanguages(); !reflect.DeepEqual(languages, []string{"en", "it", "fr", "de", "sl-IT-nedis"}) {
+		t.Errorf("unexpected languages: %v", languages)
+	}
+
+	if org := v0.GetOrganisation(); org == nil || org.URI != "urn:x-italian-pa:c_h501" {
+		t.Errorf("unexpected organisation: %+v", org)
+	}
+
+	maintenance := v0.GetMaintenance()
+	if maintenance.Type != "contract" || len(maintenance.Contractors) != 1 ||
+		maintenance.Contractors[0].Name != "Fornitore Privato SPA" || len(maintenance.Contacts) != 3 {
+		t.Errorf("unexpected maintenance: %+v", maintenance)
+	}
+
+	if desc, ok := v0.GetDescription("en"); !ok || desc.ShortDescription == "" {
+		t.Errorf("unexpected description: %+v, %v", desc, ok)
+	}
+
+	if _, ok := v0.GetDescription("es"); ok {
+		t.Error("unexpected description in a missing language")
+	}
+
+	if v0.GetLogo() != "" || len(v0.GetCategories()) == 0 {
+		t.Errorf("unexpected logo and categories: %q, %v", v0.GetLogo(), v0.GetCategories())
+	}
+
+	// The draft version is only a warning.
+	v1, err := p.Parse("testdata/v1/valid/valid.yml")
+	if hasErrors(err) {
+		t.Fatal(err)
+	}
+
+	if _, ok := v1.(*PublicCodeV1); !ok {
+		t.Fatalf("unexpected type %T", v1)
+	}
+
+	if v1.GetName() != "TestSoftware" || v1.GetLicense() != "MIT" || v1.GetDevelopmentStatus() != "stable" {
+		t.Errorf("unexpected values: %q, %q, %q", v1.GetName(), v1.GetLicense(), v1.GetDevelopmentStatus())
+	}
+
+	if maintenance := v1.GetMaintenance(); maintenance.Type != "none" || maintenance.Contractors != nil {
+		t.Errorf("unexpected maintenance: %+v", maintenance)
+	}
+
+	if v1.GetOrganisation() != nil || v1.GetLogo() != "" || v1.GetCategories() != nil {
+		t.Errorf("unexpected optional values: %+v, %q, %v", v1.GetOrganisation(), v1.GetLogo(), v1.GetCategories())
+	}
+
+	if desc, ok := v1.GetDescription("en"); !ok || desc.ShortDescription != "A test software for v1 parsing." {
+		t.Errorf("unexpected description: %+v, %v", desc, ok)
+	}
+}
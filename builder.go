@@ -0,0 +1,96 @@
+package publiccode
+
+// Constructors for the sections of PublicCodeV0 and PublicCodeV1, for
+// building publiccode data in code, eg.
+//
+//	pc.Maintenance = publiccode.NewMaintenanceV0("community").
+//		WithContacts(publiccode.ContactV0{Name: "Francesco Rossi"})
+//
+// The With methods return a modified copy. Parser.Validate checks the result.
+
+// NewLegalV0 returns the legal section with license, an SPDX expression.
+func NewLegalV0(license string) LegalV0 {
+	return LegalV0{License: license}
+}
+
+// WithMainCopyrightOwner returns l with the main copyright owner.
+func (l LegalV0) WithMainCopyrightOwner(owner string) LegalV0 {
+	l.MainCopyrightOwner = &owner
+
+	return l
+}
+
+// NewMaintenanceV0 returns the maintenance section of maintenanceType, one
+// of "internal", "contract", "community" or "none".
+func NewMaintenanceV0(maintenanceType string) MaintenanceV0 {
+	return MaintenanceV0{Type: maintenanceType}
+}
+
+// WithContractors returns m with contractors added.
+func (m MaintenanceV0) WithContractors(contractors ...ContractorV0) MaintenanceV0 {
+	m.Contractors = appendTo(m.Contractors, contractors)
+
+	return m
+}
+
+// WithContacts returns m with contacts added.
+func (m MaintenanceV0) WithContacts(contacts ...ContactV0) MaintenanceV0 {
+	m.Contacts = appendTo(m.Contacts, contacts)
+
+	return m
+}
+
+// NewLocalisationV0 returns the localisation section, with the languages
+// the software is available in.
+func NewLocalisationV0(localisationReady bool, availableLanguages ...string) LocalisationV0 {
+	return LocalisationV0{LocalisationReady: &localisationReady, AvailableLanguages: availableLanguages}
+}
+
+// NewLegalV1 is like NewLegalV0, for PublicCodeV1.
+func NewLegalV1(license string) LegalV1 {
+	return LegalV1{License: license}
+}
+
+// WithMainCopyrightOwner returns l with the main copyright owner.
+func (l LegalV1) WithMainCopyrightOwner(owner string) LegalV1 {
+	l.MainCopyrightOwner = &owner
+
+	return l
+}
+
+// NewMaintenanceV1 is like NewMaintenanceV0, for PublicCodeV1.
+func NewMaintenanceV1(maintenanceType string) MaintenanceV1 {
+	return MaintenanceV1{Type: maintenanceType}
+}
+
+// WithContractors returns m with contractors added.
+func (m MaintenanceV1) WithContractors(contractors ...ContractorV1) MaintenanceV1 {
+	m.Contractors = appendTo(m.Contractors, contractors)
+
+	return m
+}
+
+// WithContacts returns m with contacts added.
+func (m MaintenanceV1) WithContacts(contacts ...ContactV1) MaintenanceV1 {
+	m.Contacts = appendTo(m.Contacts, contacts)
+
+	return m
+}
+
+// NewLocalisationV1 is like NewLocalisationV0, for PublicCodeV1.
+func NewLocalisationV1(localisationReady bool, availableLanguages ...string) LocalisationV1 {
+	return LocalisationV1{LocalisationReady: &localisationReady, AvailableLanguages: availableLanguages}
+}
+
+// appendTo returns a new list with the items of list, if any, and items,
+// leaving list untouched.
+func appendTo[T any](list *[]T, items []T) *[]T {
+	var all []T
+	if list != nil {
+		all = append(all, *list...)
+	}
+
+	all = append(all, items...)
+
+	return &all
+}
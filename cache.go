@@ -0,0 +1,341 @@
+package publiccode
+
+import (
+	"bufio"
+	"container/list"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ReachabilityCache stores the outcome of the network checks performed while
+// parsing (eg. URLs being reachable or the raw URL of a code repository), so
+// they can be reused across Parse calls and Parser instances.
+//
+// Implementations must be safe for concurrent use.
+type ReachabilityCache interface {
+	// Get returns the result stored for key, and false if there's none or
+	// it has expired.
+	Get(key string) (ReachabilityResult, bool)
+
+	// Set stores result for key.
+	Set(key string, result ReachabilityResult)
+}
+
+// ReachabilityResult is the outcome of a network check.
+type ReachabilityResult struct {
+	// OK is true if the check succeeded (eg. the URL is reachable).
+	OK bool `json:"ok"`
+
+	// Value is the value resolved by the check, if any
+	// (eg. the raw root URL of a code repository).
+	Value string `json:"value,omitempty"`
+
+	// Error is the reason the check failed, if it did.
+	Error string `json:"error,omitempty"`
+}
+
+// cacheTTL holds how long successful and failed results are kept.
+// A non positive duration means that kind of results is not cached.
+type cacheTTL struct {
+	positive time.Duration
+	negative time.Duration
+}
+
+// expiry returns when result, stored at now, expires, and false if it
+// shouldn't be stored at all.
+func (t cacheTTL) expiry(now time.Time, result ReachabilityResult) (time.Time, bool) {
+	ttl := t.negative
+	if result.OK {
+		ttl = t.positive
+	}
+
+	if ttl <= 0 {
+		return time.Time{}, false
+	}
+
+	return now.Add(ttl), true
+}
+
+type cacheEntry struct {
+	Key     string             `json:"key"`
+	Result  ReachabilityResult `json:"result"`
+	Expires time.Time          `json:"expires"`
+}
+
+// MemoryReachabilityCache is an in-memory ReachabilityCache that evicts the
+// least recently used entries once full.
+type MemoryReachabilityCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     cacheTTL
+	entries map[string]*list.Element
+	lru     *list.List
+	now     func() time.Time
+}
+
+// NewMemoryReachabilityCache returns a MemoryReachabilityCache holding up to
+// size entries. Successful results are kept for positiveTTL and failed ones
+// for negativeTTL; a zero TTL disables caching that kind of results.
+func NewMemoryReachabilityCache(size int, positiveTTL, negativeTTL time.Duration) *MemoryReachabilityCache {
+	return &MemoryReachabilityCache{
+		size:    size,
+		ttl:     cacheTTL{positive: positiveTTL, negative: negativeTTL},
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		now:     time.Now,
+	}
+}
+
+// Get implements ReachabilityCache.
+func (c *MemoryReachabilityCache) Get(key string) (ReachabilityResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return ReachabilityResult{}, false
+	}
+
+	entry, _ := elem.Value.(*cacheEntry)
+	if !c.now().Before(entry.Expires) {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+
+		return ReachabilityResult{}, false
+	}
+
+	c.lru.MoveToFront(elem)
+
+	return entry.Result, true
+}
+
+// Set implements ReachabilityCache.
+func (c *MemoryReachabilityCache) Set(key string, result ReachabilityResult) {
+	if c.size <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires, ok := c.ttl.expiry(c.now(), result)
+	if !ok {
+		return
+	}
+
+	if elem, found := c.entries[key]; found {
+		elem.Value = &cacheEntry{Key: key, Result: result, Expires: expires}
+		c.lru.MoveToFront(elem)
+
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(&cacheEntry{Key: key, Result: result, Expires: expires})
+
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		entry, _ := oldest.Value.(*cacheEntry)
+
+		c.lru.Remove(oldest)
+		delete(c.entries, entry.Key)
+	}
+}
+
+// Len returns the number of entries in the cache, including expired ones not
+// evicted yet.
+func (c *MemoryReachabilityCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+// FileReachabilityCache is a ReachabilityCache persisted to a file, so the
+// results survive restarts.
+//
+// Entries are appended to the file as they're stored, and expired ones are
+// dropped the next time the file is opened.
+type FileReachabilityCache struct {
+	mu      sync.Mutex
+	ttl     cacheTTL
+	entries map[string]cacheEntry
+	file    *os.File
+	err     error
+	now     func() time.Time
+}
+
+// OpenFileReachabilityCache opens, or creates, the cache file at path.
+// Successful results are kept for positiveTTL and failed ones for negativeTTL;
+// a zero TTL disables caching that kind of results.
+//
+// The caller must call Close when done.
+func OpenFileReachabilityCache(path string, positiveTTL, negativeTTL time.Duration) (*FileReachabilityCache, error) {
+	c := &FileReachabilityCache{
+		ttl:     cacheTTL{positive: positiveTTL, negative: negativeTTL},
+		entries: make(map[string]cacheEntry),
+		now:     time.Now,
+	}
+
+	if err := c.load(path); err != nil {
+		return nil, err
+	}
+
+	if err := c.compact(path); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0o600) //nolint:gosec // path is chosen by the caller
+	if err != nil {
+		return nil, fmt.Errorf("opening cache file: %w", err)
+	}
+
+	c.file = f
+
+	return c, nil
+}
+
+// load reads the non expired entries from the file at path, if it exists.
+// Later entries for the same key override the earlier ones.
+func (c *FileReachabilityCache) load(path string) error {
+	f, err := os.Open(path) //nolint:gosec // path is chosen by the caller
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("opening cache file: %w", err)
+	}
+
+	defer f.Close()
+
+	now := c.now()
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		var entry cacheEntry
+
+		// Skip corrupted lines (eg. a partial write), the check will just run again.
+		if json.Unmarshal(scanner.Bytes(), &entry) != nil {
+			continue
+		}
+
+		if now.Before(entry.Expires) {
+			c.entries[entry.Key] = entry
+		} else {
+			delete(c.entries, entry.Key)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading cache file: %w", err)
+	}
+
+	return nil
+}
+
+// compact rewrites the file at path with just the entries currently loaded.
+func (c *FileReachabilityCache) compact(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("compacting cache file: %w", err)
+	}
+
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+
+	for _, entry := range c.entries {
+		if err = enc.Encode(entry); err != nil {
+			break
+		}
+	}
+
+	if err == nil {
+		err = w.Flush()
+	}
+
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+
+		return fmt.Errorf("compacting cache file: %w", err)
+	}
+
+	return nil
+}
+
+// Get implements ReachabilityCache.
+func (c *FileReachabilityCache) Get(key string) (ReachabilityResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || !c.now().Before(entry.Expires) {
+		return ReachabilityResult{}, false
+	}
+
+	return entry.Result, true
+}
+
+// Set implements ReachabilityCache. Errors writing to the file are reported
+// by Close.
+func (c *FileReachabilityCache) Set(key string, result ReachabilityResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires, ok := c.ttl.expiry(c.now(), result)
+	if !ok {
+		return
+	}
+
+	entry := cacheEntry{Key: key, Result: result, Expires: expires}
+	c.entries[key] = entry
+
+	if c.err != nil || c.file == nil {
+		return
+	}
+
+	b, err := json.Marshal(entry)
+	if err == nil {
+		_, err = c.file.Write(append(b, '\n'))
+	}
+
+	if err != nil {
+		c.err = fmt.Errorf("writing cache file: %w", err)
+	}
+}
+
+// Close closes the cache file, returning the first error encountered while
+// writing to it, if any.
+func (c *FileReachabilityCache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.file == nil {
+		return c.err
+	}
+
+	err := c.file.Close()
+	c.file = nil
+
+	if c.err != nil {
+		return c.err
+	}
+
+	if err != nil {
+		return fmt.Errorf("closing cache file: %w", err)
+	}
+
+	return nil
+}
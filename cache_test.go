@@ -0,0 +1,269 @@
+package publiccode
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeClock is a controllable time source for the caches.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.t
+}
+
+func TestMemoryReachabilityCacheTTL(t *testing.T) {
+	clock := &fakeClock{t: time.Now()}
+
+	c := NewMemoryReachabilityCache(10, time.Hour, time.Minute)
+	c.now = clock.now
+
+	c.Set("ok", ReachabilityResult{OK: true})
+	c.Set("ko", ReachabilityResult{Error: "not found"})
+
+	if r, ok := c.Get("ok"); !ok || !r.OK {
+		t.Errorf("expected cached positive result, got %v, %v", r, ok)
+	}
+	if r, ok := c.Get("ko"); !ok || r.Error != "not found" {
+		t.Errorf("expected cached negative result, got %v, %v", r, ok)
+	}
+
+	clock.t = clock.t.Add(2 * time.Minute)
+
+	if _, ok := c.Get("ko"); ok {
+		t.Error("expected negative result to be expired")
+	}
+	if _, ok := c.Get("ok"); !ok {
+		t.Error("expected positive result to still be cached")
+	}
+
+	clock.t = clock.t.Add(2 * time.Hour)
+
+	if _, ok := c.Get("ok"); ok {
+		t.Error("expected positive result to be expired")
+	}
+}
+
+func TestMemoryReachabilityCacheZeroTTL(t *testing.T) {
+	c := NewMemoryReachabilityCache(10, time.Hour, 0)
+
+	c.Set("ko", ReachabilityResult{Error: "not found"})
+
+	if _, ok := c.Get("ko"); ok {
+		t.Error("expected negative results not to be cached with a zero TTL")
+	}
+}
+
+func TestMemoryReachabilityCacheLRU(t *testing.T) {
+	c := NewMemoryReachabilityCache(2, time.Hour, time.Hour)
+
+	c.Set("a", ReachabilityResult{OK: true})
+	c.Set("b", ReachabilityResult{OK: true})
+
+	// Use "a", so "b" becomes the least recently used.
+	c.Get("a")
+	c.Set("c", ReachabilityResult{OK: true})
+
+	if c.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", c.Len())
+	}
+	if _, ok := c.Get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Error("expected a to be cached")
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Error("expected c to be cached")
+	}
+}
+
+func TestFileReachabilityCachePersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.jsonl")
+
+	c, err := OpenFileReachabilityCache(path, time.Hour, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("ok", ReachabilityResult{OK: true, Value: "https://example.com/raw/"})
+	c.Set("ko", ReachabilityResult{Error: "not found"})
+	c.Set("ko", ReachabilityResult{Error: "still not found"})
+
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err = OpenFileReachabilityCache(path, time.Hour, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if r, ok := c.Get("ok"); !ok || r.Value != "https://example.com/raw/" {
+		t.Errorf("expected persisted positive result, got %v, %v", r, ok)
+	}
+	if r, ok := c.Get("ko"); !ok || r.Error != "still not found" {
+		t.Errorf("expected latest persisted negative result, got %v, %v", r, ok)
+	}
+}
+
+func TestFileReachabilityCacheDropsExpired(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.jsonl")
+
+	c, err := OpenFileReachabilityCache(path, time.Millisecond, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("ok", ReachabilityResult{OK: true})
+	c.Close()
+
+	time.Sleep(5 * time.Millisecond)
+
+	c, err = OpenFileReachabilityCache(path, time.Hour, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Close()
+
+	if _, ok := c.Get("ok"); ok {
+		t.Error("expected expired entry to be dropped")
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b) != 0 {
+		t.Errorf("expected compacted file to be empty, got %q", b)
+	}
+}
+
+func TestFileReachabilityCacheCorruptedLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.jsonl")
+
+	content := `{"key":"ok","result":{"ok":true},"expires":"2999-01-01T00:00:00Z"}` + "\n{\"key\":\"trunc\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := OpenFileReachabilityCache(path, time.Hour, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if _, ok := c.Get("ok"); !ok {
+		t.Error("expected valid entry to be loaded")
+	}
+}
+
+func TestParserReachabilityCacheShared(t *testing.T) {
+	var hits atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	cache := NewMemoryReachabilityCache(100, time.Hour, time.Hour)
+
+	for range 2 {
+		p, err := NewParser(ParserConfig{ReachabilityCache: cache, AllowNetworkToPrivateHosts: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ok, _ := url.Parse(srv.URL + "/ok")
+		if reachable, err := p.isReachable(context.Background(), *ok); !reachable {
+			t.Errorf("expected reachable, got %v", err)
+		}
+
+		missing, _ := url.Parse(srv.URL + "/missing")
+		if reachable, err := p.isReachable(context.Background(), *missing); reachable || err == nil {
+			t.Error("expected not reachable with an error")
+		}
+	}
+
+	if n := hits.Load(); n != 2 {
+		t.Errorf("expected 2 requests to the server, got %d", n)
+	}
+}
+
+func TestParserReachabilityCacheAuth(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, _, ok := r.BasicAuth(); !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	cache := NewMemoryReachabilityCache(100, time.Hour, time.Hour)
+	u, _ := url.Parse(srv.URL + "/private")
+
+	withAuth, err := NewParser(ParserConfig{
+		ReachabilityCache:          cache,
+		AllowNetworkToPrivateHosts: true,
+		Domain:                     Domain{UseTokenFor: []string{u.Host}, BasicAuth: []string{"user:token"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reachable, err := withAuth.isReachable(context.Background(), *u); !reachable {
+		t.Errorf("expected reachable with the credentials, got %v", err)
+	}
+
+	// The result with the credentials must not be reused without them.
+	withoutAuth, err := NewParser(ParserConfig{ReachabilityCache: cache, AllowNetworkToPrivateHosts: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reachable, _ := withoutAuth.isReachable(context.Background(), *u); reachable {
+		t.Error("expected not reachable without the credentials")
+	}
+}
+
+func TestParserReachabilityCacheSkipsAborted(t *testing.T) {
+	cache := NewMemoryReachabilityCache(100, time.Hour, time.Hour)
+
+	p, err := NewParser(ParserConfig{ReachabilityCache: cache})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	u, _ := url.Parse("https://example.com/")
+	if reachable, _ := p.isReachable(ctx, *u); reachable {
+		t.Error("expected aborted check not to be reachable")
+	}
+
+	if cache.Len() != 0 {
+		t.Errorf("expected aborted checks not to be cached, got %d entries", cache.Len())
+	}
+}
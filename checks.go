@@ -0,0 +1,160 @@
+package publiccode
+
+import (
+	"errors"
+	"strings"
+)
+
+// Check is the type of check a ValidationError or ValidationWarning comes
+// from, eg. "url-unreachable". It's used to match diagnostics in a
+// SeverityPolicy and in suppression comments.
+//
+// Each Check has a stable diagnostic code, set in the Code field of the
+// diagnostics it reports (eg. "PC-URL-UNREACHABLE"). See Checks for the
+// catalogue.
+type Check string
+
+// The Checks run by the Parser. See Checks for their documentation.
+const (
+	CheckInvalidYAML          Check = "invalid-yaml"
+	CheckUnsupportedVersion   Check = "unsupported-version"
+	CheckOldVersion           Check = "old-version"
+	CheckDraftVersion         Check = "draft-version"
+	CheckUnknownField         Check = "unknown-field"
+	CheckWrongType            Check = "wrong-type"
+	CheckInvalidValue         Check = "invalid-value"
+	CheckDeprecatedKey        Check = "deprecated-key"
+	CheckLowercaseCountryCode Check = "lowercase-country-code"
+	CheckDuplicateKey         Check = "duplicate-key"
+	CheckDeprecatedLicense    Check = "deprecated-license"
+	CheckNonOpenSourceLicense Check = "non-open-source-license"
+	CheckInvalidPath          Check = "invalid-path"
+	CheckURLUnreachable       Check = "url-unreachable"
+	CheckInvalidRepository    Check = "invalid-repository"
+	CheckInvalidLogo          Check = "invalid-logo"
+	CheckInvalidImage         Check = "invalid-image"
+	CheckFileNotFound         Check = "file-not-found"
+	CheckInvalidVideo         Check = "invalid-video"
+	CheckAborted              Check = "check-aborted"
+	CheckCustomRule           Check = "custom-rule"
+	CheckInvalidSuppression   Check = "invalid-suppression"
+	CheckUnusedSuppression    Check = "unused-suppression"
+	CheckInternal             Check = "internal-error"
+)
+
+// CheckInfo documents a Check.
+type CheckInfo struct {
+	Check Check `json:"check"`
+
+	// Code is the stable diagnostic code (eg. "PC-URL-UNREACHABLE").
+	Code string `json:"code"`
+
+	Description string `json:"description"`
+}
+
+// catalogue is every Check, with its documentation.
+//
+// The codes are derived from the Checks and are part of the public API:
+// never rename or reuse a Check.
+var catalogue = []struct {
+	check       Check
+	description string
+}{
+	{CheckInvalidYAML, "The file is not valid UTF-8, not valid YAML (or JSON) or has more than one document."},
+	{CheckUnsupportedVersion, "publiccodeYmlVersion is not a supported version."},
+	{CheckOldVersion, "publiccodeYmlVersion is older than the latest version."},
+	{CheckDraftVersion, "publiccodeYmlVersion is a draft version, parsed with ParserConfig.EnableDraftVersions."},
+	{CheckUnknownField, "A key not in the Standard."},
+	{CheckWrongType, "A value of the wrong type (eg. a list instead of a string)."},
+	{CheckInvalidValue, "A required key is missing or a value is not allowed by the Standard."},
+	{CheckDeprecatedKey, "A key deprecated by the Standard."},
+	{CheckLowercaseCountryCode, "A country code in lowercase."},
+	{CheckDuplicateKey, "A key present under both its deprecated and current name."},
+	{CheckDeprecatedLicense, "A deprecated SPDX license ID in legal.license (eg. GPL-3.0, not GPL-3.0-only)."},
+	{CheckNonOpenSourceLicense, "legal.license is not an open source license, neither OSI-approved nor FSF-free."},
+	{CheckInvalidPath, "An absolute path or a file:// URL where a relative path or an HTTP(s) URL is expected."},
+	{CheckURLUnreachable, "A URL that can't be reached."},
+	{CheckInvalidRepository, "url is not a code repository, or its files can't be accessed."},
+	{CheckInvalidLogo, "The logo doesn't exist or is not a valid image."},
+	{CheckInvalidImage, "A screenshot doesn't exist or is not an image."},
+	{CheckFileNotFound, "A referenced file doesn't exist."},
+	{CheckInvalidVideo, "A video URL not supporting oEmbed."},
+	{CheckAborted, "An external check that couldn't complete (eg. ParseTimeout expired)."},
+	{CheckCustomRule, "A diagnostic from a Rule in ParserConfig not setting its own Code."},
+	{CheckInvalidSuppression, "A suppression comment naming an unknown check."},
+	{CheckUnusedSuppression, "A suppression comment not suppressing anything."},
+	{CheckInternal, "An error in the parser itself or in its environment."},
+}
+
+// Checks returns the catalogue of all the Checks, with their diagnostic codes.
+func Checks() []CheckInfo {
+	infos := make([]CheckInfo, 0, len(catalogue))
+	for _, c := range catalogue {
+		infos = append(infos, CheckInfo{Check: c.check, Code: c.check.Code(), Description: c.description})
+	}
+
+	return infos
+}
+
+// LookupCode returns the CheckInfo for the diagnostic code, and false if
+// there's no such code.
+func LookupCode(code string) (CheckInfo, bool) {
+	for _, c := range catalogue {
+		if c.check.Code() == code {
+			return CheckInfo{Check: c.check, Code: code, Description: c.description}, true
+		}
+	}
+
+	return CheckInfo{}, false
+}
+
+// Code returns the stable diagnostic code of c (eg. "PC-URL-UNREACHABLE").
+func (c Check) Code() string {
+	return "PC-" + strings.ToUpper(string(c))
+}
+
+// valid returns whether c is a known Check.
+func (c Check) valid() bool {
+	_, ok := LookupCode(c.Code())
+
+	return ok
+}
+
+// withCheck returns err, a ValidationError or ValidationWarning, with the
+// Code of check.
+func withCheck(check Check, err error) error {
+	switch e := err.(type) { //nolint:errorlint // err is always created by the caller
+	case ValidationError:
+		e.Code = check.Code()
+
+		return e
+	case ValidationWarning:
+		e.Code = check.Code()
+
+		return e
+	}
+
+	return err
+}
+
+// checkOf returns the Check err comes from, if known.
+func checkOf(err error) Check {
+	var (
+		valErr  ValidationError
+		valWarn ValidationWarning
+		code    string
+	)
+
+	switch {
+	case errors.As(err, &valErr):
+		code = valErr.Code
+	case errors.As(err, &valWarn):
+		code = valWarn.Code
+	}
+
+	if info, ok := LookupCode(code); ok {
+		return info.Check
+	}
+
+	return ""
+}
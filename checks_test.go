@@ -0,0 +1,69 @@
+package publiccode
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestChecksCatalogue(t *testing.T) {
+	seen := map[string]bool{}
+
+	for _, info := range Checks() {
+		if seen[info.Code] {
+			t.Errorf("duplicate code %s", info.Code)
+		}
+		seen[info.Code] = true
+
+		if !strings.HasPrefix(info.Code, "PC-") || info.Code != strings.ToUpper(info.Code) {
+			t.Errorf("malformed code %s", info.Code)
+		}
+		if info.Description == "" {
+			t.Errorf("no description for %s", info.Code)
+		}
+
+		found, ok := LookupCode(info.Code)
+		if !ok || found != info {
+			t.Errorf("LookupCode(%s) = %v, %v", info.Code, found, ok)
+		}
+	}
+
+	if _, ok := LookupCode("PC-NO-SUCH-CODE"); ok {
+		t.Error("expected unknown code not to be found")
+	}
+}
+
+func TestCheckCodes(t *testing.T) {
+	for check, code := range map[Check]string{
+		CheckURLUnreachable: "PC-URL-UNREACHABLE",
+		CheckDeprecatedKey:  "PC-DEPRECATED-KEY",
+		CheckUnknownField:   "PC-UNKNOWN-FIELD",
+	} {
+		if check.Code() != code {
+			t.Errorf("expected code %s for %s, got %s", code, check, check.Code())
+		}
+	}
+}
+
+func TestCodeInJSON(t *testing.T) {
+	p, err := NewParser(ParserConfig{DisableNetwork: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = p.Parse("testdata/v0/valid_with_warnings/no-network/authorsFile.yml")
+
+	b, jsonErr := json.Marshal(err)
+	if jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+
+	var results []map[string]any
+	if err := json.Unmarshal(b, &results); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 1 || results[0]["code"] != "PC-DEPRECATED-KEY" || results[0]["type"] != "warning" {
+		t.Errorf("unexpected JSON %s", b)
+	}
+}
@@ -7,4 +7,11 @@ var (
 	OembedSchemes []byte
 	//go:embed it/ipa_codes.txt
 	ItIpaCodes string
+	// SpdxLicenses is json/licenses.json of the SPDX License List
+	// (https://github.com/spdx/license-list-data) with only the fields used,
+	// regenerated from its latest release by the "Update external files"
+	// workflow (.github/workflows/file-freshness-pr.yml).
+	//
+	//go:embed spdx_licenses.json
+	SpdxLicenses []byte
 )
@@ -0,0 +1,269 @@
+package publiccode
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"time"
+)
+
+// identityKeys are the keys telling apart the items of a list of mappings
+// (eg. the contacts by name), in order of preference.
+var identityKeys = []string{"id", "uri", "name"}
+
+// reContractorKey matches the keys of a contractor and of its until date.
+var reContractorKey = regexp.MustCompile(`^maintenance\.contractors\[\d+\](\.until)?$`)
+
+// Diff returns the changes from publiccode a to b: the keys added, removed
+// or modified, with their Old and New values. Lists of mappings are matched
+// by item (eg. the contractors by name) rather than by position.
+//
+// v0 files are compared after Upgrade, so that files of different v0
+// versions, or with deprecated keys, only differ in what they say. Neither
+// a nor b is modified.
+//
+// The changes needing attention are Flagged: a different license,
+// developmentStatus going to "obsolete", maintenance.type going to "none"
+// and the contractors whose contract expired.
+func Diff(a, b PublicCode) []Change {
+	a, _ = Upgrade(a)
+	b, _ = Upgrade(b)
+
+	old, _ := valueOf(a).(mapping)
+	value, _ := valueOf(b).(mapping)
+
+	changes := diffMappings("", old, value)
+	for i := range changes {
+		flagChange(&changes[i], time.Now())
+	}
+
+	return changes
+}
+
+// diffMappings returns the changes from the mapping old to value, at key.
+func diffMappings(key string, old mapping, value mapping) []Change {
+	var changes []Change
+
+	for _, e := range old {
+		v, ok := value.get(e.key)
+		if !ok {
+			changes = append(changes, removedValue(joinKey(key, e.key), e.value))
+
+			continue
+		}
+
+		changes = append(changes, diffValues(joinKey(key, e.key), e.value, v)...)
+	}
+
+	for _, e := range value {
+		if _, ok := old.get(e.key); !ok {
+			changes = append(changes, addedValue(joinKey(key, e.key), e.value))
+		}
+	}
+
+	return changes
+}
+
+// diffValues returns the changes from old to value, at key.
+func diffValues(key string, old any, value any) []Change {
+	switch old := old.(type) {
+	case mapping:
+		if value, ok := value.(mapping); ok {
+			return diffMappings(key, old, value)
+		}
+	case []any:
+		if value, ok := value.([]any); ok {
+			return diffLists(key, old, value)
+		}
+	}
+
+	if reflect.DeepEqual(old, value) {
+		return nil
+	}
+
+	return []Change{{
+		Kind:        ChangeModified,
+		Key:         key,
+		Old:         plain(old),
+		New:         plain(value),
+		Description: fmt.Sprintf("changed from %s to %s", describe(old), describe(value)),
+	}}
+}
+
+// diffLists returns the changes from the list old to value, at key. The
+// items are matched by their identity (see identityOf), the removed ones
+// keyed with their index in old and the others with their index in value.
+func diffLists(key string, old []any, value []any) []Change {
+	var changes []Change
+
+	matched := make([]bool, len(value))
+
+	for i, item := range old {
+		j := matchItem(item, i, value, matched)
+		if j < 0 {
+			changes = append(changes, removedValue(fmt.Sprintf("%s[%d]", key, i), item))
+
+			continue
+		}
+
+		matched[j] = true
+
+		changes = append(changes, diffValues(fmt.Sprintf("%s[%d]", key, j), item, value[j])...)
+	}
+
+	for j, item := range value {
+		if !matched[j] {
+			changes = append(changes, addedValue(fmt.Sprintf("%s[%d]", key, j), item))
+		}
+	}
+
+	return changes
+}
+
+// matchItem returns the index of the item of value not matched yet that is
+// the same as item, at index i of its list, or -1 if there's none.
+//
+// Scalars match an equal one. Mappings match the one with their identity,
+// or the one at the same index if they have none.
+func matchItem(item any, i int, value []any, matched []bool) int {
+	id, hasID := identityOf(item)
+
+	for j, v := range value {
+		if matched[j] {
+			continue
+		}
+
+		if _, isMapping := item.(mapping); !isMapping {
+			if reflect.DeepEqual(item, v) {
+				return j
+			}
+
+			continue
+		}
+
+		if vid, ok := identityOf(v); ok && hasID && reflect.DeepEqual(vid, id) || !ok && !hasID && i == j {
+			return j
+		}
+	}
+
+	return -1
+}
+
+// identityOf returns the value of the first of identityKeys in item, if it's
+// a mapping having one.
+func identityOf(item any) (any, bool) {
+	m, ok := item.(mapping)
+	if !ok {
+		return nil, false
+	}
+
+	for _, key := range identityKeys {
+		if v, ok := m.get(key); ok {
+			return v, true
+		}
+	}
+
+	return nil, false
+}
+
+// flagChange sets Flagged and explains why in the Description of the
+// changes needing attention, as of now.
+func flagChange(change *Change, now time.Time) {
+	switch {
+	case change.Key == "legal.license" && change.Kind == ChangeModified:
+		change.Flagged = true
+		change.Description = "license " + change.Description
+	case change.Key == "developmentStatus" && change.New == "obsolete":
+		change.Flagged = true
+		change.Description += ", the software is obsolete"
+	case change.Key == "maintenance.type" && change.New == "none":
+		change.Flagged = true
+		change.Description += ", the software is no longer maintained"
+	case change.Kind != ChangeRemoved && expiredContractor(change.Key, change.New, now):
+		change.Flagged = true
+		change.Description += ", the contract has expired"
+	}
+}
+
+// expiredContractor returns whether value, at key, is a contractor or the
+// until date of one, with the date before now.
+func expiredContractor(key string, value any, now time.Time) bool {
+	m := reContractorKey.FindStringSubmatch(key)
+	if m == nil {
+		return false
+	}
+
+	until := value
+	if m[1] == "" {
+		contractor, _ := value.(map[string]any)
+		until = contractor["until"]
+	}
+
+	s, ok := until.(string)
+	if !ok {
+		return false
+	}
+
+	date, err := time.Parse(time.DateOnly, s)
+
+	return err == nil && date.Before(now)
+}
+
+func addedValue(key string, value any) Change {
+	return Change{Kind: ChangeAdded, Key: key, New: plain(value), Description: "added " + describe(value)}
+}
+
+func removedValue(key string, old any) Change {
+	return Change{Kind: ChangeRemoved, Key: key, Old: plain(old), Description: "removed " + describe(old)}
+}
+
+// joinKey returns the key of child under key.
+func joinKey(key string, child string) string {
+	if key == "" {
+		return child
+	}
+
+	return key + "." + child
+}
+
+// describe returns v quoted, for the Description of a Change. Mappings and
+// lists are shown as such.
+func describe(v any) string {
+	switch v := v.(type) {
+	case mapping:
+		if id, ok := identityOf(v); ok {
+			return fmt.Sprintf("'%v'", id)
+		}
+
+		return "a mapping"
+	case []any:
+		return fmt.Sprintf("a list of %d", len(v))
+	case nil:
+		return "nothing"
+	}
+
+	return fmt.Sprintf("'%v'", v)
+}
+
+// plain returns the tree v with maps instead of mappings, for the Old and
+// New values of a Change.
+func plain(v any) any {
+	switch v := v.(type) {
+	case mapping:
+		out := make(map[string]any, len(v))
+		for _, e := range v {
+			out[e.key] = plain(e.value)
+		}
+
+		return out
+	case []any:
+		out := make([]any, 0, len(v))
+		for _, item := range v {
+			out = append(out, plain(item))
+		}
+
+		return out
+	}
+
+	return v
+}
@@ -0,0 +1,106 @@
+package publiccode
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	p, err := NewParser(ParserConfig{DisableExternalChecks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pc, err := p.Parse("testdata/v0/valid/valid.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := pc.(PublicCodeV0)
+
+	b := a
+	b.Legal.License = "MIT"
+	b.DevelopmentStatus = "obsolete"
+	b.Localisation.AvailableLanguages = []string{"en", "it", "de", "sl-IT-nedis", "es"}
+	b.Maintenance.Contractors = &[]ContractorV0{{Name: "Fornitore Nuovo SRL", Until: "2020-01-01"}}
+
+	changes := Diff(a, &b)
+
+	expected := []Change{
+		{
+			Kind: ChangeModified, Key: "developmentStatus", Old: "development", New: "obsolete",
+			Description: "changed from 'development' to 'obsolete', the software is obsolete", Flagged: true,
+		},
+		{
+			Kind: ChangeModified, Key: "legal.license", Old: "AGPL-3.0-or-later", New: "MIT",
+			Description: "license changed from 'AGPL-3.0-or-later' to 'MIT'", Flagged: true,
+		},
+		{
+			Kind: ChangeRemoved, Key: "maintenance.contractors[0]",
+			Old: map[string]any{
+				"name": "Fornitore Privato SPA", "website": "https://developers.italia.it", "until": "2019-01-01",
+			},
+			Description: "removed 'Fornitore Privato SPA'",
+		},
+		{
+			Kind: ChangeAdded, Key: "maintenance.contractors[0]",
+			New:         map[string]any{"name": "Fornitore Nuovo SRL", "until": "2020-01-01"},
+			Description: "added 'Fornitore Nuovo SRL', the contract has expired", Flagged: true,
+		},
+		{
+			Kind: ChangeRemoved, Key: "localisation.availableLanguages[2]", Old: "fr",
+			Description: "removed 'fr'",
+		},
+		{
+			Kind: ChangeAdded, Key: "localisation.availableLanguages[4]", New: "es",
+			Description: "added 'es'",
+		},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("wrong changes:\n%v\n- instead of:\n%v", changes, expected)
+	}
+
+	if changes := Diff(a, a); changes != nil {
+		t.Errorf("unexpected changes diffing the same file: %v", changes)
+	}
+}
+
+func TestDiffAfterUpgrade(t *testing.T) {
+	p, err := NewParser(ParserConfig{DisableExternalChecks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Deprecated keys and lowercase country codes are not changes.
+	pc, _ := p.Parse("testdata/v0/valid_with_warnings/valid_with_IT_riuso_codiceIPA.yml")
+	upgraded, _ := Upgrade(pc)
+
+	if changes := Diff(pc, upgraded); changes != nil {
+		t.Errorf("unexpected changes: %v", changes)
+	}
+}
+
+func TestFlagChangeContractor(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		change  Change
+		flagged bool
+	}{
+		{Change{Kind: ChangeModified, Key: "maintenance.contractors[1].until", New: "2025-05-31"}, true},
+		{Change{Kind: ChangeModified, Key: "maintenance.contractors[1].until", New: "2025-06-02"}, false},
+		{Change{Kind: ChangeAdded, Key: "maintenance.contractors[0]", New: map[string]any{"until": "2024-01-01"}}, true},
+		{Change{Kind: ChangeRemoved, Key: "maintenance.contractors[0]", Old: map[string]any{"until": "2024-01-01"}}, false},
+		{Change{Kind: ChangeModified, Key: "maintenance.contractors[0].name", New: "2024-01-01"}, false},
+	}
+
+	for _, test := range tests {
+		change := test.change
+		flagChange(&change, now)
+
+		if change.Flagged != test.flagged {
+			t.Errorf("%s: expected Flagged to be %v", change.Key, test.flagged)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package publiccode
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/url"
+
+	"github.com/goccy/go-yaml/lexer"
+	"github.com/goccy/go-yaml/token"
+)
+
+// ParseAll is like Parse, but the file at uri is a YAML stream of one or more
+// publiccode.yml documents separated by "---" (eg. the ones of the components
+// of a monorepo, concatenated).
+//
+// It returns the PublicCode and the ValidationResults, nil if valid, of each
+// document. Their positions are relative to the whole stream.
+// The error is only returned if the stream can't be read at all.
+func (p *Parser) ParseAll(uri string) ([]PublicCode, []ValidationResults, error) {
+	return p.ParseAllContext(context.Background(), uri)
+}
+
+// ParseAllContext is like ParseAll, with the context semantics of
+// ParseContext.
+func (p *Parser) ParseAllContext(ctx context.Context, uri string) ([]PublicCode, []ValidationResults, error) {
+	ctx, cancel := p.withParseTimeout(ctx)
+	defer cancel()
+
+	stream, fileURL, err := p.open(ctx, uri)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer stream.Close()
+
+	return p.parseStreamAll(ctx, stream, fileURL)
+}
+
+// ParseStreamAll is like ParseAll, reading the YAML stream from in.
+func (p *Parser) ParseStreamAll(in io.Reader) ([]PublicCode, []ValidationResults, error) {
+	return p.ParseStreamAllContext(context.Background(), in)
+}
+
+// ParseStreamAllContext is like ParseStreamAll, with the context semantics of
+// ParseStreamContext.
+func (p *Parser) ParseStreamAllContext(ctx context.Context, in io.Reader) ([]PublicCode, []ValidationResults, error) {
+	ctx, cancel := p.withParseTimeout(ctx)
+	defer cancel()
+
+	return p.parseStreamAll(ctx, in, nil)
+}
+
+func (p *Parser) parseStreamAll(
+	ctx context.Context, in io.Reader, fileURL *url.URL,
+) ([]PublicCode, []ValidationResults, error) {
+	b, err := p.readStream(in)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	docs := splitDocuments(b)
+
+	publiccodes := make([]PublicCode, 0, len(docs))
+	results := make([]ValidationResults, 0, len(docs))
+
+	for _, doc := range docs {
+		publiccode, err := p.parseBytes(ctx, doc, fileURL)
+
+		var vr ValidationResults
+		errors.As(err, &vr)
+
+		publiccodes = append(publiccodes, publiccode)
+		results = append(results, vr)
+	}
+
+	return publiccodes, results, nil
+}
+
+// splitDocuments splits the YAML stream src into its documents, starting at
+// each "---". Each one is padded with empty lines, so that the positions in it
+// are the same as in the whole stream.
+//
+// Documents with nothing but comments (eg. before the first "---") are left
+// out, unless there are no others.
+func splitDocuments(src []byte) [][]byte {
+	lines := bytes.SplitAfter(src, []byte("\n"))
+
+	var (
+		docs       [][]byte
+		start      = 1
+		hasContent = false
+	)
+
+	appendDoc := func(end int) {
+		if hasContent {
+			doc := bytes.Repeat([]byte("\n"), start-1)
+			docs = append(docs, append(doc, bytes.Join(lines[start-1:end-1], nil)...))
+		}
+	}
+
+	for _, tk := range lexer.Tokenize(string(src)) {
+		switch tk.Type { //nolint:exhaustive // all the others are content
+		case token.DocumentHeaderType:
+			appendDoc(tk.Position.Line)
+			start, hasContent = tk.Position.Line, false
+		case token.CommentType, token.DocumentEndType, token.DirectiveType:
+		default:
+			hasContent = true
+		}
+	}
+
+	appendDoc(len(lines) + 1)
+
+	if len(docs) == 0 {
+		return [][]byte{src}
+	}
+
+	return docs
+}
@@ -0,0 +1,127 @@
+package publiccode
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseStreamAll(t *testing.T) {
+	valid, err := os.ReadFile("testdata/v0/valid/no-network/valid_with_supports.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invalid, err := os.ReadFile("testdata/v0/invalid/no-network/categories_typo.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The second document starts after the first one and the "---" line.
+	offset := strings.Count(string(valid), "\n") + 1
+
+	p, err := NewParser(ParserConfig{DisableExternalChecks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream := string(valid) + "---\n" + string(invalid)
+
+	publiccodes, results, err := p.ParseStreamAll(strings.NewReader(stream))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(publiccodes) != 2 || len(results) != 2 {
+		t.Fatalf("got %d publiccodes and %d results, want 2", len(publiccodes), len(results))
+	}
+
+	if results[0] != nil {
+		t.Errorf("unexpected results for the first document: %v", results[0])
+	}
+
+	if publiccodes[0] == nil || publiccodes[1] == nil {
+		t.Fatalf("unexpected nil PublicCode: %v", publiccodes)
+	}
+
+	expected := ValidationResults{
+		ValidationError{
+			Key: "categorie", Description: "unknown field \"categorie\", did you mean 'categories'?",
+			Line: 17 + offset, Column: 1, EndLine: 17 + offset, EndColumn: 9, Code: "PC-UNKNOWN-FIELD",
+		},
+	}
+	if !reflect.DeepEqual(results[1], expected) {
+		t.Errorf("wrong results for the second document:\n%v\n- instead of:\n%v", results[1], expected)
+	}
+
+	// Parse keeps rejecting multiple documents.
+	if _, err := p.ParseStream(strings.NewReader(stream)); err == nil ||
+		!strings.Contains(err.Error(), "multiple YAML documents") {
+		t.Errorf("unexpected error from ParseStream: %v", err)
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	valid, err := os.ReadFile("testdata/v0/valid/no-network/valid_with_supports.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(t.TempDir(), "publiccode.yml")
+
+	// A leading "---" and comments don't make an empty document.
+	stream := "# Components\n---\n" + string(valid) + "---\n" + string(valid) + "---\n"
+	if err := os.WriteFile(file, []byte(stream), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := NewParser(ParserConfig{DisableExternalChecks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publiccodes, results, err := p.ParseAll(file)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(publiccodes) != 2 {
+		t.Fatalf("got %d publiccodes, want 2", len(publiccodes))
+	}
+
+	for i, vr := range results {
+		if vr != nil {
+			t.Errorf("unexpected results for document %d: %v", i, vr)
+		}
+	}
+
+	if _, _, err := p.ParseAll("/nonexistent/path/file.yml"); err == nil {
+		t.Error("expected error for nonexistent file")
+	}
+}
+
+func TestSplitDocuments(t *testing.T) {
+	tests := []struct {
+		src      string
+		expected []string
+	}{
+		{"a: 1\n", []string{"a: 1\n"}},
+		{"", []string{""}},
+		{"a: 1\n---\nb: 2\n", []string{"a: 1\n", "\n---\nb: 2\n"}},
+		{"# c\n---\na: 1\n...\n---\nb: 2", []string{"\n---\na: 1\n...\n", "\n\n\n\n---\nb: 2"}},
+		{"a: |\n  ---\n  x\n---\nb: 2\n", []string{"a: |\n  ---\n  x\n", "\n\n\n---\nb: 2\n"}},
+	}
+
+	for _, test := range tests {
+		var got []string
+		for _, doc := range splitDocuments([]byte(test.src)) {
+			got = append(got, string(doc))
+		}
+
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("splitDocuments(%q) = %q, want %q", test.src, got, test.expected)
+		}
+	}
+}
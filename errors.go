@@ -1,7 +1,9 @@
 package publiccode
 
 import (
+	"cmp"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -20,6 +22,19 @@ type ValidationError struct {
 	Description string `json:"description"`
 	Line        int    `json:"line"`
 	Column      int    `json:"column"`
+
+	// EndLine and EndColumn are the position of the last character of the
+	// diagnostic's range in the source (eg. the end of the offending value),
+	// or zero if the diagnostic has no position.
+	EndLine   int `json:"endLine,omitempty"`
+	EndColumn int `json:"endColumn,omitempty"`
+
+	// Code is a stable identifier of the kind of diagnostic (eg.
+	// "PC-URL-UNREACHABLE"), see Checks.
+	Code string `json:"code,omitempty"`
+
+	// Fix, if not nil, fixes the issue automatically. See ApplyFixes.
+	Fix *Fix `json:"fix,omitempty"`
 }
 
 func (e ValidationError) Error() string {
@@ -48,10 +63,6 @@ func newValidationError(key string, description string) ValidationError {
 	return ValidationError{Key: key, Description: description}
 }
 
-func newValidationErrorf(key string, description string, args ...any) ValidationError {
-	return newValidationError(key, fmt.Sprintf(description, args...))
-}
-
 //nolint:errname,lll // ValidationWarning is intentionally named as a warning, not an error, even though it implements error.
 type ValidationWarning ValidationError
 
@@ -87,3 +98,27 @@ func (vr ValidationResults) Error() string {
 
 	return strings.Join(s, "\n")
 }
+
+// compareByKeyAndLine orders ValidationResults entries by key and then by line.
+func compareByKeyAndLine(a, b error) int {
+	keyA, lineA := keyAndLine(a)
+	keyB, lineB := keyAndLine(b)
+
+	return cmp.Or(strings.Compare(keyA, keyB), cmp.Compare(lineA, lineB))
+}
+
+func keyAndLine(err error) (string, int) {
+	var (
+		ve ValidationError
+		vw ValidationWarning
+	)
+
+	switch {
+	case errors.As(err, &ve):
+		return ve.Key, ve.Line
+	case errors.As(err, &vw):
+		return vw.Key, vw.Line
+	}
+
+	return "", 0
+}
@@ -50,6 +50,18 @@ func TestValidationErrorMarshalJSON(t *testing.T) {
 	}
 }
 
+func TestValidationErrorMarshalJSONNoPosition(t *testing.T) {
+	b, err := ValidationError{Description: "check aborted"}.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"key":"","description":"check aborted","line":0,"column":0,"type":"error"}`
+	if string(b) != want {
+		t.Errorf("got %s, want %s", b, want)
+	}
+}
+
 func TestValidationWarningMarshalJSON(t *testing.T) {
 	e := ValidationWarning{Key: "foo", Description: "minor", Line: 2, Column: 3}
 	b, err := e.MarshalJSON()
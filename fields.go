@@ -1,20 +1,21 @@
 package publiccode
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"strings"
 
-	"github.com/alranel/go-vcsurl/v2"
 	urlutil "github.com/italia/publiccode-parser-go/v5/internal"
 )
 
-type validateFn func(publiccode PublicCode, parser *Parser, network bool, baseURL *url.URL) error
+type validateFn func(ctx context.Context, publiccode PublicCode, parser *Parser, network bool, baseURL *url.URL) error
 
 // validateFieldsV0 validates publiccode.yml with additional rules not validatable
 // with go-playground/validator
 // It returns any error encountered as ValidationResults.
-func validateFieldsV0(publiccode PublicCode, parser *Parser, network bool, baseURL *url.URL) error { //nolint:maintidx
+func validateFieldsV0(ctx context.Context, publiccode PublicCode, parser *Parser, network bool, baseURL *url.URL) error { //nolint:maintidx
 	publiccodev0, ok := publiccode.(*PublicCodeV0)
 	if !ok {
 		return fmt.Errorf("internal: expected *PublicCodeV0, got %T", publiccode) //nolint:err113 // dynamic type name
@@ -22,67 +23,44 @@ func validateFieldsV0(publiccode PublicCode, parser *Parser, network bool, baseU
 
 	var vr ValidationResults
 
+	// Checks on external resources are slow, they're queued here and run
+	// concurrently at the end.
+	var checks []externalCheck
+
 	checksNetwork := network && !parser.disableExternalChecks
 
 	if checksNetwork && publiccodev0.URL != nil {
-		if reachable, err := parser.isReachable(*(*url.URL)(publiccodev0.URL)); !reachable {
-			vr = append(vr, newValidationErrorf("url", "'%s' not reachable: %s", publiccodev0.URL, err.Error()))
-		}
-
-		if !vcsurl.IsRepo((*url.URL)(publiccodev0.URL)) {
-			vr = append(vr, newValidationError("url", "is not a valid code repository"))
-		}
+		checks = append(checks, parser.repoURLCheck(publiccodev0.URL))
 	}
 
 	if checksNetwork && publiccodev0.LandingURL != nil {
-		if reachable, err := parser.isReachable(*(*url.URL)(publiccodev0.LandingURL)); !reachable {
-			vr = append(vr, newValidationErrorf(
-				"landingURL",
-				"'%s' not reachable: %s", publiccodev0.LandingURL, err.Error(),
-			))
-		}
+		checks = append(checks, parser.reachableCheck("landingURL", publiccodev0.LandingURL))
 	}
 
 	if checksNetwork && publiccodev0.Roadmap != nil {
-		if reachable, err := parser.isReachable(*(*url.URL)(publiccodev0.Roadmap)); !reachable {
-			vr = append(vr, newValidationErrorf(
-				"roadmap",
-				"'%s' not reachable: %s", publiccodev0.Roadmap, err.Error(),
-			))
-		}
+		checks = append(checks, parser.reachableCheck("roadmap", publiccodev0.Roadmap))
 	}
 
 	if publiccodev0.Logo != nil && *publiccodev0.Logo != "" {
-		if _, err := isRelativePathOrURL(*publiccodev0.Logo, "logo"); err != nil {
+		if _, err := parser.isRelativePathOrURL(*publiccodev0.Logo, "logo"); err != nil {
 			vr = append(vr, err)
 		} else if !parser.disableExternalChecks {
-			u := toAbsoluteURL(*publiccodev0.Logo, baseURL, network)
-			if u != nil {
-				validLogo, err := parser.validLogo(*u, network)
-				if !validLogo {
-					vr = append(vr, newValidationError("logo", err.Error()))
-				}
-			}
+			checks = append(checks, parser.logoCheck("logo", *publiccodev0.Logo, baseURL, network))
 		}
 	}
 
 	if publiccodev0.MonochromeLogo != nil && *publiccodev0.MonochromeLogo != "" {
-		vr = append(vr, ValidationWarning{
-			"monochromeLogo",
-			"This key is DEPRECATED and will be removed in the future. Use 'logo' instead",
-			0, 0,
-		})
+		vr = append(vr, withCheck(CheckDeprecatedKey, ValidationWarning{
+			Key:         "monochromeLogo",
+			Description: parser.message("deprecated_key_use", "logo"),
+		}))
 
-		if _, err := isRelativePathOrURL(*publiccodev0.MonochromeLogo, "monochromeLogo"); err != nil {
+		if _, err := parser.isRelativePathOrURL(*publiccodev0.MonochromeLogo, "monochromeLogo"); err != nil {
 			vr = append(vr, err)
 		} else if !parser.disableExternalChecks {
-			u := toAbsoluteURL(*publiccodev0.MonochromeLogo, baseURL, network)
-			if u != nil {
-				validLogo, err := parser.validLogo(*u, network)
-				if !validLogo {
-					vr = append(vr, newValidationError("monochromeLogo", err.Error()))
-				}
-			}
+			checks = append(checks, parser.logoCheck(
+				"monochromeLogo", *publiccodev0.MonochromeLogo, baseURL, network,
+			))
 		}
 	}
 
@@ -93,11 +71,11 @@ func validateFieldsV0(publiccode PublicCode, parser *Parser, network bool, baseU
 		if publiccodev0.IntendedAudience.Countries != nil {
 			for i, c := range *publiccodev0.IntendedAudience.Countries {
 				if sharedValidate.Var(c, "iso3166_1_alpha2_lower_or_upper") == nil && c == strings.ToLower(c) {
-					vr = append(vr, ValidationWarning{
-						fmt.Sprintf("intendedAudience.countries[%d]", i),
-						fmt.Sprintf("Lowercase country codes are DEPRECATED. Use uppercase instead ('%s')", strings.ToUpper(c)),
-						0, 0,
-					})
+					vr = append(vr, withCheck(CheckLowercaseCountryCode, ValidationWarning{
+						Key:         fmt.Sprintf("intendedAudience.countries[%d]", i),
+						Description: parser.message("lowercase_country_code", strings.ToUpper(c)),
+						Fix:         uppercaseFix(fmt.Sprintf("$.intendedAudience.countries[%d]", i), c),
+					}))
 				}
 			}
 		}
@@ -105,157 +83,147 @@ func validateFieldsV0(publiccode PublicCode, parser *Parser, network bool, baseU
 		if publiccodev0.IntendedAudience.UnsupportedCountries != nil {
 			for i, c := range *publiccodev0.IntendedAudience.UnsupportedCountries {
 				if sharedValidate.Var(c, "iso3166_1_alpha2_lower_or_upper") == nil && c == strings.ToLower(c) {
-					vr = append(vr, ValidationWarning{
-						fmt.Sprintf("intendedAudience.unsupportedCountries[%d]", i),
-						fmt.Sprintf("Lowercase country codes are DEPRECATED. Use uppercase instead ('%s')", strings.ToUpper(c)),
-						0, 0,
-					})
+					vr = append(vr, withCheck(CheckLowercaseCountryCode, ValidationWarning{
+						Key:         fmt.Sprintf("intendedAudience.unsupportedCountries[%d]", i),
+						Description: parser.message("lowercase_country_code", strings.ToUpper(c)),
+						Fix:         uppercaseFix(fmt.Sprintf("$.intendedAudience.unsupportedCountries[%d]", i), c),
+					}))
 				}
 			}
 		}
 	}
 
 	if publiccodev0.Legal.AuthorsFile != nil {
-		vr = append(vr, ValidationWarning{
-			"legal.authorsFile",
-			"This key is DEPRECATED and will be removed in the future. It's safe to drop it",
-			0, 0,
-		})
+		vr = append(vr, withCheck(CheckDeprecatedKey, ValidationWarning{
+			Key:         "legal.authorsFile",
+			Description: parser.message("deprecated_key"),
+		}))
 
-		if _, err := isRelativePathOrURL(*publiccodev0.Legal.AuthorsFile, "legal.authorsFile"); err != nil {
+		if _, err := parser.isRelativePathOrURL(*publiccodev0.Legal.AuthorsFile, "legal.authorsFile"); err != nil {
 			vr = append(vr, err)
 		} else if !parser.disableExternalChecks {
-			u := toAbsoluteURL(*publiccodev0.Legal.AuthorsFile, baseURL, network)
-			if u != nil {
-				exists, err := parser.fileExists(*u, network)
-				if !exists {
-					vr = append(vr, newValidationErrorf(
-						"legal.authorsFile", "'%s' does not exist: %s", urlutil.DisplayURL(u), err.Error(),
-					))
-				}
-			}
+			checks = append(checks, parser.fileExistsCheck(
+				"legal.authorsFile", *publiccodev0.Legal.AuthorsFile, baseURL, network,
+			))
 		}
 	}
 
+	vr = append(vr, parser.licenseWarnings(publiccodev0.Legal.License)...)
+
 	if publiccodev0.Legal.RepoOwner != nil {
-		vr = append(vr, ValidationWarning{
-			"legal.repoOwner",
-			"This key is DEPRECATED and will be removed in the future. Use 'organisation.name' instead",
-			0, 0,
-		})
+		vr = append(vr, withCheck(CheckDeprecatedKey, ValidationWarning{
+			Key:         "legal.repoOwner",
+			Description: parser.message("deprecated_key_use", "organisation.name"),
+		}))
 	}
 
 	if publiccodev0.InputTypes != nil {
-		vr = append(vr, ValidationWarning{
-			"inputTypes",
-			"This key is DEPRECATED and will be removed in the future. It's safe to drop it",
-			0, 0,
-		})
+		vr = append(vr, withCheck(CheckDeprecatedKey, ValidationWarning{
+			Key:         "inputTypes",
+			Description: parser.message("deprecated_key"),
+		}))
 	}
 
 	if publiccodev0.OutputTypes != nil {
-		vr = append(vr, ValidationWarning{
-			"outputTypes",
-			"This key is DEPRECATED and will be removed in the future. It's safe to drop it",
-			0, 0,
-		})
+		vr = append(vr, withCheck(CheckDeprecatedKey, ValidationWarning{
+			Key:         "outputTypes",
+			Description: parser.message("deprecated_key"),
+		}))
 	}
 
 	for lang, desc := range publiccodev0.Description {
 		if len(desc.GenericName) > 0 {
-			vr = append(vr, ValidationWarning{
-				fmt.Sprintf("description.%s.genericName", lang),
-				"This key is DEPRECATED and will be removed in the future. It's safe to drop it", 0, 0,
-			})
+			vr = append(vr, withCheck(CheckDeprecatedKey, ValidationWarning{
+				Key:         fmt.Sprintf("description.%s.genericName", lang),
+				Description: parser.message("deprecated_key"),
+			}))
 		}
 
 		if checksNetwork && desc.Documentation != nil {
-			if reachable, err := parser.isReachable(*(*url.URL)(desc.Documentation)); !reachable {
-				vr = append(vr, newValidationErrorf(
-					fmt.Sprintf("description.%s.documentation", lang),
-					"'%s' not reachable: %s", desc.Documentation, err.Error(),
-				))
-			}
+			checks = append(checks, parser.reachableCheck(
+				fmt.Sprintf("description.%s.documentation", lang), desc.Documentation,
+			))
 		}
 
 		if checksNetwork && desc.APIDocumentation != nil {
-			if reachable, err := parser.isReachable(*(*url.URL)(desc.APIDocumentation)); !reachable {
-				vr = append(vr, newValidationErrorf(
-					fmt.Sprintf("description.%s.apiDocumentation", lang),
-					"'%s' not reachable: %s", desc.APIDocumentation, err.Error(),
-				))
-			}
+			checks = append(checks, parser.reachableCheck(
+				fmt.Sprintf("description.%s.apiDocumentation", lang), desc.APIDocumentation,
+			))
 		}
 
 		for i, v := range desc.Screenshots {
 			keyName := fmt.Sprintf("description.%s.screenshots[%d]", lang, i)
-			if _, err := isRelativePathOrURL(v, keyName); err != nil {
+			if _, err := parser.isRelativePathOrURL(v, keyName); err != nil {
 				vr = append(vr, err)
 			} else if !parser.disableExternalChecks {
-				u := toAbsoluteURL(v, baseURL, network)
-				if u != nil {
-					isImage, err := parser.isImageFile(*u, network)
-					if !isImage {
-						vr = append(vr, newValidationErrorf(
-							keyName,
-							"'%s' is not an image: %s", v, err.Error(),
-						))
-					}
-				}
+				checks = append(checks, parser.screenshotCheck(keyName, v, baseURL, network))
 			}
 		}
 
 		for i, v := range desc.Videos {
 			err := parser.checkOEmbedURL((*url.URL)(v))
 			if err != nil {
-				vr = append(vr, newValidationErrorf(
+				vr = append(vr, withCheck(CheckInvalidVideo, newValidationError(
 					fmt.Sprintf("description.%s.videos[%d]", lang, i),
-					"'%s' is not a valid video URL supporting oEmbed: %s", v, err.Error(),
-				))
+					parser.message("invalid_video", v, err),
+				)))
 			}
 		}
 	}
 
-	it := publiccodev0.IT
+	it, itKey := publiccodev0.IT, "IT"
 
 	if publiccodev0.It != nil {
-		vr = append(vr, ValidationWarning{
-			"it",
-			"Lowercase country codes are DEPRECATED and will be removed in the future. Use 'IT' instead", 0, 0,
-		})
+		warn := ValidationWarning{
+			Key:         "it",
+			Description: parser.message("lowercase_it"),
+		}
 
-		it = publiccodev0.It
+		if publiccodev0.IT == nil {
+			warn.Fix = &Fix{Edits: []Edit{{Op: EditRename, Path: "$.it", Value: "IT"}}}
+		}
+
+		vr = append(vr, withCheck(CheckDeprecatedKey, warn))
+
+		it, itKey = publiccodev0.It, "it"
 	}
 
 	if publiccodev0.IT != nil && publiccodev0.It != nil {
-		vr = append(vr, newValidationError("it", "'IT' key already present. Remove this key"))
+		vr = append(vr, withCheck(CheckDuplicateKey, newValidationError("it", parser.message("duplicate_it"))))
 
-		it = publiccodev0.IT
+		it, itKey = publiccodev0.IT, "IT"
 	}
 
 	if it != nil {
 		if it.Conforme != nil {
-			vr = append(vr, ValidationWarning{
-				"IT.conforme",
-				"This key is DEPRECATED and will be removed in the future. It's safe to drop it", 0, 0,
-			})
+			vr = append(vr, withCheck(CheckDeprecatedKey, ValidationWarning{
+				Key:         "IT.conforme",
+				Description: parser.message("deprecated_key"),
+			}))
 		}
 
 		if it.Riuso.CodiceIPA != "" {
 			if sharedValidate.Var(it.Riuso.CodiceIPA, "is_italian_ipa_code") == nil {
-				vr = append(vr, ValidationWarning{
-					"IT.riuso.codiceIPA",
-					fmt.Sprintf(
-						"This key is DEPRECATED and will be removed in the future. "+
-							"Use 'organisation.uri' and set it to 'urn:x-italian-pa:%s' instead",
-						it.Riuso.CodiceIPA,
-					),
-					0, 0,
-				})
+				warn := ValidationWarning{
+					Key:         "IT.riuso.codiceIPA",
+					Description: parser.message("deprecated_codice_ipa", it.Riuso.CodiceIPA),
+				}
+
+				// Don't overwrite an existing organisation.uri.
+				if publiccodev0.Organisation == nil || publiccodev0.Organisation.URI == "" {
+					warn.Fix = &Fix{Edits: []Edit{
+						{Op: EditRemove, Path: "$." + itKey + ".riuso.codiceIPA"},
+						{Op: EditSet, Path: "$.organisation.uri", Value: "urn:x-italian-pa:" + it.Riuso.CodiceIPA},
+					}}
+				}
+
+				vr = append(vr, withCheck(CheckDeprecatedKey, warn))
 			}
 		}
 	}
 
+	vr = append(vr, parser.runExternalChecks(ctx, checks)...)
+
 	if len(vr) == 0 {
 		return nil
 	}
@@ -266,7 +234,7 @@ func validateFieldsV0(publiccode PublicCode, parser *Parser, network bool, baseU
 // validateFieldsV1 validates publiccode.yml with additional rules not validatable
 // with go-playground/validator
 // It returns any error encountered as ValidationResults.
-func validateFieldsV1(publiccode PublicCode, parser *Parser, network bool, baseURL *url.URL) error {
+func validateFieldsV1(ctx context.Context, publiccode PublicCode, parser *Parser, network bool, baseURL *url.URL) error {
 	publiccodev1, ok := publiccode.(*PublicCodeV1)
 	if !ok {
 		return fmt.Errorf("internal: expected *PublicCodeV1, got %T", publiccode) //nolint:err113 // dynamic type name
@@ -274,47 +242,29 @@ func validateFieldsV1(publiccode PublicCode, parser *Parser, network bool, baseU
 
 	var vr ValidationResults
 
+	// Checks on external resources are slow, they're queued here and run
+	// concurrently at the end.
+	var checks []externalCheck
+
 	checksNetwork := network && !parser.disableExternalChecks
 
 	if checksNetwork && publiccodev1.URL != nil {
-		if reachable, err := parser.isReachable(*(*url.URL)(publiccodev1.URL)); !reachable {
-			vr = append(vr, newValidationErrorf("url", "'%s' not reachable: %s", publiccodev1.URL, err.Error()))
-		}
-
-		if !vcsurl.IsRepo((*url.URL)(publiccodev1.URL)) {
-			vr = append(vr, newValidationError("url", "is not a valid code repository"))
-		}
+		checks = append(checks, parser.repoURLCheck(publiccodev1.URL))
 	}
 
 	if checksNetwork && publiccodev1.LandingURL != nil {
-		if reachable, err := parser.isReachable(*(*url.URL)(publiccodev1.LandingURL)); !reachable {
-			vr = append(vr, newValidationErrorf(
-				"landingURL",
-				"'%s' not reachable: %s", publiccodev1.LandingURL, err.Error(),
-			))
-		}
+		checks = append(checks, parser.reachableCheck("landingURL", publiccodev1.LandingURL))
 	}
 
 	if checksNetwork && publiccodev1.Roadmap != nil {
-		if reachable, err := parser.isReachable(*(*url.URL)(publiccodev1.Roadmap)); !reachable {
-			vr = append(vr, newValidationErrorf(
-				"roadmap",
-				"'%s' not reachable: %s", publiccodev1.Roadmap, err.Error(),
-			))
-		}
+		checks = append(checks, parser.reachableCheck("roadmap", publiccodev1.Roadmap))
 	}
 
 	if publiccodev1.Logo != nil && *publiccodev1.Logo != "" {
-		if _, err := isRelativePathOrURL(*publiccodev1.Logo, "logo"); err != nil {
+		if _, err := parser.isRelativePathOrURL(*publiccodev1.Logo, "logo"); err != nil {
 			vr = append(vr, err)
 		} else if !parser.disableExternalChecks {
-			u := toAbsoluteURL(*publiccodev1.Logo, baseURL, network)
-			if u != nil {
-				validLogo, err := parser.validLogo(*u, network)
-				if !validLogo {
-					vr = append(vr, newValidationError("logo", err.Error()))
-				}
-			}
+			checks = append(checks, parser.logoCheck("logo", *publiccodev1.Logo, baseURL, network))
 		}
 	}
 
@@ -325,11 +275,11 @@ func validateFieldsV1(publiccode PublicCode, parser *Parser, network bool, baseU
 		if publiccodev1.IntendedAudience.Countries != nil {
 			for i, c := range *publiccodev1.IntendedAudience.Countries {
 				if sharedValidate.Var(c, "iso3166_1_alpha2_lower_or_upper") == nil && c == strings.ToLower(c) {
-					vr = append(vr, ValidationWarning{
-						fmt.Sprintf("intendedAudience.countries[%d]", i),
-						fmt.Sprintf("Lowercase country codes are DEPRECATED. Use uppercase instead ('%s')", strings.ToUpper(c)),
-						0, 0,
-					})
+					vr = append(vr, withCheck(CheckLowercaseCountryCode, ValidationWarning{
+						Key:         fmt.Sprintf("intendedAudience.countries[%d]", i),
+						Description: parser.message("lowercase_country_code", strings.ToUpper(c)),
+						Fix:         uppercaseFix(fmt.Sprintf("$.intendedAudience.countries[%d]", i), c),
+					}))
 				}
 			}
 		}
@@ -337,64 +287,53 @@ func validateFieldsV1(publiccode PublicCode, parser *Parser, network bool, baseU
 		if publiccodev1.IntendedAudience.UnsupportedCountries != nil {
 			for i, c := range *publiccodev1.IntendedAudience.UnsupportedCountries {
 				if sharedValidate.Var(c, "iso3166_1_alpha2_lower_or_upper") == nil && c == strings.ToLower(c) {
-					vr = append(vr, ValidationWarning{
-						fmt.Sprintf("intendedAudience.unsupportedCountries[%d]", i),
-						fmt.Sprintf("Lowercase country codes are DEPRECATED. Use uppercase instead ('%s')", strings.ToUpper(c)),
-						0, 0,
-					})
+					vr = append(vr, withCheck(CheckLowercaseCountryCode, ValidationWarning{
+						Key:         fmt.Sprintf("intendedAudience.unsupportedCountries[%d]", i),
+						Description: parser.message("lowercase_country_code", strings.ToUpper(c)),
+						Fix:         uppercaseFix(fmt.Sprintf("$.intendedAudience.unsupportedCountries[%d]", i), c),
+					}))
 				}
 			}
 		}
 	}
 
+	vr = append(vr, parser.licenseWarnings(publiccodev1.Legal.License)...)
+
 	for lang, desc := range publiccodev1.Description {
 		if checksNetwork && desc.Documentation != nil {
-			if reachable, err := parser.isReachable(*(*url.URL)(desc.Documentation)); !reachable {
-				vr = append(vr, newValidationErrorf(
-					fmt.Sprintf("description.%s.documentation", lang),
-					"'%s' not reachable: %s", desc.Documentation, err.Error(),
-				))
-			}
+			checks = append(checks, parser.reachableCheck(
+				fmt.Sprintf("description.%s.documentation", lang), desc.Documentation,
+			))
 		}
 
 		if checksNetwork && desc.APIDocumentation != nil {
-			if reachable, err := parser.isReachable(*(*url.URL)(desc.APIDocumentation)); !reachable {
-				vr = append(vr, newValidationErrorf(
-					fmt.Sprintf("description.%s.apiDocumentation", lang),
-					"'%s' not reachable: %s", desc.APIDocumentation, err.Error(),
-				))
-			}
+			checks = append(checks, parser.reachableCheck(
+				fmt.Sprintf("description.%s.apiDocumentation", lang), desc.APIDocumentation,
+			))
 		}
 
 		for i, v := range desc.Screenshots {
 			keyName := fmt.Sprintf("description.%s.screenshots[%d]", lang, i)
-			if _, err := isRelativePathOrURL(v, keyName); err != nil {
+			if _, err := parser.isRelativePathOrURL(v, keyName); err != nil {
 				vr = append(vr, err)
 			} else if !parser.disableExternalChecks {
-				u := toAbsoluteURL(v, baseURL, network)
-				if u != nil {
-					isImage, err := parser.isImageFile(*u, network)
-					if !isImage {
-						vr = append(vr, newValidationErrorf(
-							keyName,
-							"'%s' is not an image: %s", v, err.Error(),
-						))
-					}
-				}
+				checks = append(checks, parser.screenshotCheck(keyName, v, baseURL, network))
 			}
 		}
 
 		for i, v := range desc.Videos {
 			err := parser.checkOEmbedURL((*url.URL)(v))
 			if err != nil {
-				vr = append(vr, newValidationErrorf(
+				vr = append(vr, withCheck(CheckInvalidVideo, newValidationError(
 					fmt.Sprintf("description.%s.videos[%d]", lang, i),
-					"'%s' is not a valid video URL supporting oEmbed: %s", v, err.Error(),
-				))
+					parser.message("invalid_video", v, err),
+				)))
 			}
 		}
 	}
 
+	vr = append(vr, parser.runExternalChecks(ctx, checks)...)
+
 	if len(vr) == 0 {
 		return nil
 	}
@@ -402,18 +341,122 @@ func validateFieldsV1(publiccode PublicCode, parser *Parser, network bool, baseU
 	return vr
 }
 
+// repoURLCheck returns the externalCheck verifying that u is reachable and is
+// a code repository.
+func (p *Parser) repoURLCheck(u *URL) externalCheck {
+	return func(ctx context.Context) ValidationResults {
+		var vr ValidationResults
+
+		if reachable, err := p.isReachable(ctx, *(*url.URL)(u)); !reachable {
+			vr = append(vr, p.newExternalCheckError(CheckURLUnreachable, "url", err, p.message("not_reachable", u, err)))
+		}
+
+		isRepo, err := p.isRepo(ctx, (*url.URL)(u))
+
+		switch {
+		case err != nil:
+			vr = append(vr, p.newExternalCheckError(CheckInvalidRepository, "url", err, err.Error()))
+		case !isRepo:
+			vr = append(vr, withCheck(CheckInvalidRepository, newValidationError("url", p.message("invalid_repository"))))
+		}
+
+		return vr
+	}
+}
+
+// reachableCheck returns the externalCheck verifying that u in key is reachable.
+func (p *Parser) reachableCheck(key string, u *URL) externalCheck {
+	return func(ctx context.Context) ValidationResults {
+		if reachable, err := p.isReachable(ctx, *(*url.URL)(u)); !reachable {
+			return ValidationResults{p.newExternalCheckError(CheckURLUnreachable, key, err, p.message("not_reachable", u, err))}
+		}
+
+		return nil
+	}
+}
+
+// logoCheck returns the externalCheck verifying that file in key is a valid logo.
+func (p *Parser) logoCheck(key string, file string, baseURL *url.URL, network bool) externalCheck {
+	return func(ctx context.Context) ValidationResults {
+		u := toAbsoluteURL(ctx, file, baseURL, network)
+		if u == nil {
+			return nil
+		}
+
+		if validLogo, err := p.validLogo(ctx, *u, network); !validLogo {
+			return ValidationResults{p.newExternalCheckError(CheckInvalidLogo, key, err, err.Error())}
+		}
+
+		return nil
+	}
+}
+
+// screenshotCheck returns the externalCheck verifying that file in key is an image.
+func (p *Parser) screenshotCheck(key string, file string, baseURL *url.URL, network bool) externalCheck {
+	return func(ctx context.Context) ValidationResults {
+		u := toAbsoluteURL(ctx, file, baseURL, network)
+		if u == nil {
+			return nil
+		}
+
+		if isImage, err := p.isImageFile(ctx, *u, network); !isImage {
+			return ValidationResults{p.newExternalCheckError(CheckInvalidImage, key, err, p.message("not_an_image", file, err))}
+		}
+
+		return nil
+	}
+}
+
+// fileExistsCheck returns the externalCheck verifying that file in key exists.
+func (p *Parser) fileExistsCheck(key string, file string, baseURL *url.URL, network bool) externalCheck {
+	return func(ctx context.Context) ValidationResults {
+		u := toAbsoluteURL(ctx, file, baseURL, network)
+		if u == nil {
+			return nil
+		}
+
+		if exists, err := p.fileExists(ctx, *u, network); !exists {
+			return ValidationResults{p.newExternalCheckError(
+				CheckFileNotFound, key, err, p.message("file_not_found", urlutil.DisplayURL(u), err),
+			)}
+		}
+
+		return nil
+	}
+}
+
+// newExternalCheckError returns the ValidationError, tagged with check and
+// with description, for an external check on key that failed with err. Checks
+// that couldn't run to completion are reported as aborted rather than as a
+// problem with the resource.
+func (p *Parser) newExternalCheckError(check Check, key string, err error, description string) error {
+	if errors.Is(err, errCheckAborted) {
+		cause := strings.TrimPrefix(err.Error(), errCheckAborted.Error()+": ")
+
+		return withCheck(CheckAborted, newValidationError(key, p.message("check_aborted", cause)))
+	}
+
+	return withCheck(check, newValidationError(key, description))
+}
+
 // isRelativePathOrURL checks whether the field contains either a relative filename
 // or an HTTP URL
 //
 //nolint:unparam
-func isRelativePathOrURL(content string, keyName string) (bool, error) {
+func (p *Parser) isRelativePathOrURL(content string, keyName string) (bool, error) {
 	if strings.HasPrefix(content, "/") {
-		return false, newValidationError(keyName, "is an absolute path. Only relative paths or HTTP(s) URLs allowed")
+		return false, withCheck(CheckInvalidPath, newValidationError(keyName, p.message("absolute_path")))
 	}
 
 	if strings.HasPrefix(content, "file:") {
-		return false, newValidationError(keyName, "is a file:// URL. Only relative paths or HTTP(s) URLs allowed")
+		return false, withCheck(CheckInvalidPath, newValidationError(keyName, p.message("file_url")))
 	}
 
 	return true, nil
 }
+
+// uppercaseFix returns the Fix replacing the country code at path with its
+// uppercase version.
+func uppercaseFix(path string, country string) *Fix {
+	return &Fix{Edits: []Edit{{Op: EditReplace, Path: path, Value: strings.ToUpper(country)}}}
+}
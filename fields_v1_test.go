@@ -1,6 +1,8 @@
 package publiccode
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -64,7 +66,7 @@ func TestValidateFieldsV1Direct(t *testing.T) {
 		},
 	}
 
-	err = validateFieldsV1(v1, p, false, base)
+	err = validateFieldsV1(context.Background(), v1, p, false, base)
 	if err != nil {
 		t.Errorf("unexpected error from validateFieldsV1: %v", err)
 	}
@@ -87,7 +89,7 @@ func TestValidateFieldsV1WithLogoAbsPath(t *testing.T) {
 		},
 	}
 
-	err := validateFieldsV1(v1, p, false, base)
+	err := validateFieldsV1(context.Background(), v1, p, false, base)
 	if err == nil {
 		t.Error("expected error for absolute logo path")
 	}
@@ -108,7 +110,7 @@ func TestValidateFieldsV1WithVideos(t *testing.T) {
 		},
 	}
 
-	err := validateFieldsV1(v1, p, false, base)
+	err := validateFieldsV1(context.Background(), v1, p, false, base)
 	if err == nil {
 		t.Error("expected error for invalid oEmbed video URL")
 	}
@@ -126,7 +128,7 @@ func TestValidateFieldsV1WithScreenshotAbsPath(t *testing.T) {
 		},
 	}
 
-	err := validateFieldsV1(v1, p, false, base)
+	err := validateFieldsV1(context.Background(), v1, p, false, base)
 	if err == nil {
 		t.Error("expected error for absolute path screenshot")
 	}
@@ -145,7 +147,7 @@ func TestValidateFieldsV1WithScreenshotRelative(t *testing.T) {
 	}
 
 	// Should not panic, may produce an error about missing file
-	_ = validateFieldsV1(v1, p, false, base)
+	_ = validateFieldsV1(context.Background(), v1, p, false, base)
 }
 
 func TestValidateFieldsV1WithNetworkChecks(t *testing.T) {
@@ -188,7 +190,7 @@ func TestValidateFieldsV1WithNetworkChecks(t *testing.T) {
 	}
 
 	// network=true, checksNetwork=true
-	err := validateFieldsV1(v1, p, true, base)
+	err := validateFieldsV1(context.Background(), v1, p, true, base)
 	// We expect the "not a valid code repository" error.
 	if err == nil {
 		t.Log("no error (URL might have been checked differently)")
@@ -228,7 +230,7 @@ func TestValidateFieldsV1WithUnreachableURLs(t *testing.T) {
 	}
 
 	// network=true, checksNetwork=true
-	err := validateFieldsV1(v1, p, true, base)
+	err := validateFieldsV1(context.Background(), v1, p, true, base)
 	// We expect errors because the URLs return 404.
 	if err == nil {
 		t.Error("expected errors for unreachable URLs")
@@ -255,7 +257,7 @@ func TestValidateFieldsV1WithAPIDocNetworkCheck(t *testing.T) {
 		},
 	}
 
-	err := validateFieldsV1(v1, p, true, base)
+	err := validateFieldsV1(context.Background(), v1, p, true, base)
 	if err == nil {
 		t.Error("expected error for unreachable API documentation URL")
 	}
@@ -286,7 +288,7 @@ func TestValidateFieldsV1WithCountriesDeprecated(t *testing.T) {
 		},
 	}
 
-	err := validateFieldsV1(v1, p, false, base)
+	err := validateFieldsV1(context.Background(), v1, p, false, base)
 	if err == nil {
 		t.Error("expected deprecation warnings for lowercase countries")
 	}
@@ -300,7 +302,7 @@ func TestValidateFieldsV0MonochromeLogoAbsPath(t *testing.T) {
 	v0 := &PublicCodeV0{}
 	v0.MonochromeLogo = &logo
 
-	err := validateFieldsV0(v0, p, false, base)
+	err := validateFieldsV0(context.Background(), v0, p, false, base)
 	vr, ok := err.(ValidationResults)
 	if !ok {
 		t.Fatal("expected ValidationResults")
@@ -308,7 +310,8 @@ func TestValidateFieldsV0MonochromeLogoAbsPath(t *testing.T) {
 
 	found := false
 	for _, e := range vr {
-		if ve, ok := e.(ValidationError); ok && ve.Key == "monochromeLogo" {
+		var ve ValidationError
+		if errors.As(e, &ve) && ve.Key == "monochromeLogo" {
 			found = true
 		}
 	}
@@ -326,7 +329,7 @@ func TestValidateFieldsV0AuthorsFileAbsPath(t *testing.T) {
 	v0 := &PublicCodeV0{}
 	v0.Legal.AuthorsFile = &authorsFile
 
-	err := validateFieldsV0(v0, p, false, base)
+	err := validateFieldsV0(context.Background(), v0, p, false, base)
 	vr, ok := err.(ValidationResults)
 	if !ok {
 		t.Fatal("expected ValidationResults")
@@ -334,7 +337,8 @@ func TestValidateFieldsV0AuthorsFileAbsPath(t *testing.T) {
 
 	found := false
 	for _, e := range vr {
-		if ve, ok := e.(ValidationError); ok && ve.Key == "legal.authorsFile" {
+		var ve ValidationError
+		if errors.As(e, &ve) && ve.Key == "legal.authorsFile" {
 			found = true
 		}
 	}
@@ -354,7 +358,7 @@ func TestValidateFieldsV0ScreenshotsAbsPath(t *testing.T) {
 		},
 	}
 
-	err := validateFieldsV0(v0, p, false, base)
+	err := validateFieldsV0(context.Background(), v0, p, false, base)
 	vr, ok := err.(ValidationResults)
 	if !ok {
 		t.Fatal("expected ValidationResults")
@@ -362,7 +366,8 @@ func TestValidateFieldsV0ScreenshotsAbsPath(t *testing.T) {
 
 	found := false
 	for _, e := range vr {
-		if ve, ok := e.(ValidationError); ok && strings.Contains(ve.Key, "screenshots") {
+		var ve ValidationError
+		if errors.As(e, &ve) && strings.Contains(ve.Key, "screenshots") {
 			found = true
 		}
 	}
@@ -392,7 +397,7 @@ func TestValidateFieldsV1LogoRelativeExternalChecks(t *testing.T) {
 	}
 
 	// /tmp/logo.svg does not exist, so validLogo should produce an error
-	err := validateFieldsV1(v1, p, false, base)
+	err := validateFieldsV1(context.Background(), v1, p, false, base)
 	if err == nil {
 		t.Error("expected error for missing logo file")
 	}
@@ -0,0 +1,402 @@
+package publiccode
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/goccy/go-yaml/token"
+)
+
+// Fix is a machine-applicable fix for a diagnostic: the Edits to make
+// together to publiccode.yml. See ApplyFixes.
+type Fix struct {
+	Edits []Edit `json:"edits"`
+}
+
+// EditOp is the kind of change made by an Edit.
+type EditOp string
+
+const (
+	// EditReplace replaces the scalar at Path with Value.
+	EditReplace EditOp = "replace"
+	// EditRename renames the key at Path to Value.
+	EditRename EditOp = "rename"
+	// EditRemove removes the key at Path, and its parents if left empty.
+	EditRemove EditOp = "remove"
+	// EditSet sets the key at Path to Value, adding it and its parents if
+	// missing.
+	EditSet EditOp = "set"
+)
+
+// Edit is a change to the node of publiccode.yml at Path.
+type Edit struct {
+	Op EditOp `json:"op"`
+
+	// Path is the YAML path of the node (eg. "$.intendedAudience.countries[0]").
+	Path string `json:"path"`
+
+	// Value is the new value for EditReplace and EditSet, or the new key for
+	// EditRename.
+	Value string `json:"value,omitempty"`
+}
+
+var (
+	errUnsupportedEdit = errors.New("unsupported edit")
+	errEmptyDocument   = errors.New("empty document")
+)
+
+// Fixes returns the Fixes attached to the diagnostics in err, a
+// ValidationResults.
+func Fixes(err error) []Fix {
+	var (
+		vr    ValidationResults
+		fixes []Fix
+	)
+
+	if !errors.As(err, &vr) {
+		return nil
+	}
+
+	for _, result := range vr {
+		var (
+			valErr  ValidationError
+			valWarn ValidationWarning
+		)
+
+		switch {
+		case errors.As(result, &valErr) && valErr.Fix != nil:
+			fixes = append(fixes, *valErr.Fix)
+		case errors.As(result, &valWarn) && valWarn.Fix != nil:
+			fixes = append(fixes, *valWarn.Fix)
+		}
+	}
+
+	return fixes
+}
+
+// ApplyFixes returns the publiccode.yml in src with fixes applied.
+//
+// Only the text of the changed nodes is rewritten, so comments, key order and
+// formatting are kept.
+func ApplyFixes(src []byte, fixes []Fix) ([]byte, error) {
+	var edits []Edit
+	for _, fix := range fixes {
+		edits = append(edits, fix.Edits...)
+	}
+
+	// Rename last, so the paths of the other edits are still valid.
+	slices.SortStableFunc(edits, func(a, b Edit) int {
+		return boolToInt(a.Op == EditRename) - boolToInt(b.Op == EditRename)
+	})
+
+	for _, edit := range edits {
+		file, err := parser.ParseBytes(src, 0)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse publiccode.yml: %w", err)
+		}
+
+		if len(file.Docs) == 0 || file.Docs[0].Body == nil {
+			return nil, fmt.Errorf("can't %s %s: %w", edit.Op, edit.Path, errEmptyDocument)
+		}
+
+		e := editor{src: src, root: file.Docs[0].Body}
+
+		switch edit.Op {
+		case EditReplace:
+			err = e.replace(edit.Path, edit.Value)
+		case EditRename:
+			err = e.rename(edit.Path, edit.Value)
+		case EditRemove:
+			err = e.remove(edit.Path)
+		case EditSet:
+			err = e.set(edit.Path, edit.Value)
+		default:
+			err = errUnsupportedEdit
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("can't %s %s: %w", edit.Op, edit.Path, err)
+		}
+
+		src = e.src
+	}
+
+	return src, nil
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// editor makes edits to the source of a YAML document, finding the nodes in
+// its AST.
+type editor struct {
+	src  []byte
+	root ast.Node
+}
+
+func (e *editor) replace(path string, value string) error {
+	node, err := e.node(path)
+	if err != nil {
+		return err
+	}
+
+	r := nodeRange(node)
+	if _, _, ok := valueEnd(node); !ok || r.line == 0 {
+		return fmt.Errorf("%w: not a scalar", errUnsupportedEdit)
+	}
+
+	e.splice(r, formatScalar(value, node.GetToken()))
+
+	return nil
+}
+
+func (e *editor) rename(path string, key string) error {
+	mv, _, err := e.mappingValue(path)
+	if err != nil {
+		return err
+	}
+
+	e.splice(tokenRange(mv.Key.GetToken()), formatScalar(key, mv.Key.GetToken()))
+
+	return nil
+}
+
+func (e *editor) remove(path string) error {
+	mv, siblings, err := e.mappingValue(path)
+	if err != nil {
+		return err
+	}
+
+	// Remove the parent key instead, if this is its only child.
+	if parent, _ := splitPath(path); len(siblings) == 1 && parent != "$" && !strings.HasSuffix(parent, "]") {
+		return e.remove(parent)
+	}
+
+	start := tokenRange(mv.Key.GetToken())
+
+	endLine, _, ok := nodeEnd(mv)
+	if !ok || !e.onlyIndentBefore(start.line, start.column) {
+		return fmt.Errorf("%w: not a block mapping key", errUnsupportedEdit)
+	}
+
+	from := e.lineOffset(start.line)
+	to := e.lineOffset(endLine + 1)
+	e.src = slices.Concat(e.src[:from], e.src[to:])
+
+	return nil
+}
+
+func (e *editor) set(path string, value string) error {
+	if _, err := e.node(path); err == nil {
+		return e.replace(path, value)
+	}
+
+	keys := strings.Split(strings.TrimPrefix(path, "$."), ".")
+
+	// Find the closest existing parent, and add the missing keys under it.
+	for i := len(keys) - 1; i >= 0; i-- {
+		parentPath := "$"
+		if i > 0 {
+			parentPath += "." + strings.Join(keys[:i], ".")
+		}
+
+		parent, err := e.node(parentPath)
+		if err != nil {
+			continue
+		}
+
+		values := mappingValues(parent)
+		if len(values) == 0 {
+			return fmt.Errorf("%w: %s is not a block mapping", errUnsupportedEdit, parentPath)
+		}
+
+		indent := strings.Repeat(" ", tokenRange(values[0].Key.GetToken()).column-1)
+
+		var b strings.Builder
+
+		for j, key := range keys[i:] {
+			b.WriteString(indent + strings.Repeat("  ", j) + key + ":")
+
+			if i+j == len(keys)-1 {
+				b.WriteString(" " + formatScalar(value, nil))
+			}
+
+			b.WriteString("\n")
+		}
+
+		endLine, _, ok := nodeEnd(values[len(values)-1])
+		if !ok {
+			return fmt.Errorf("%w: %s is not a block mapping", errUnsupportedEdit, parentPath)
+		}
+
+		at := e.lineOffset(endLine + 1)
+
+		text := b.String()
+		if at > 0 && e.src[at-1] != '\n' {
+			text = "\n" + text
+		}
+
+		e.src = slices.Concat(e.src[:at], []byte(text), e.src[at:])
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", errUnsupportedEdit, path)
+}
+
+// node returns the node at path.
+func (e *editor) node(path string) (ast.Node, error) {
+	if path == "$" {
+		return e.root, nil
+	}
+
+	p, err := yaml.PathString(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path: %w", err)
+	}
+
+	node, err := p.FilterNode(e.root)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path: %w", err)
+	}
+
+	if node == nil {
+		return nil, yaml.ErrNotFoundNode
+	}
+
+	return node, nil
+}
+
+// mappingValue returns the key-value pair at path, and the ones in the same
+// mapping.
+func (e *editor) mappingValue(path string) (*ast.MappingValueNode, []*ast.MappingValueNode, error) {
+	parentPath, key := splitPath(path)
+	if key == "" || strings.HasPrefix(key, "[") {
+		return nil, nil, fmt.Errorf("%w: not a key", errUnsupportedEdit)
+	}
+
+	parent, err := e.node(parentPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values := mappingValues(parent)
+	for _, mv := range values {
+		if mv.Key.GetToken() != nil && mv.Key.GetToken().Value == key {
+			return mv, values, nil
+		}
+	}
+
+	return nil, nil, yaml.ErrNotFoundNode
+}
+
+// splitPath splits a YAML path into the path of the parent node and the last
+// key (eg. "c" or "[0]" for "$.a.b.c" or "$.a.b[0]").
+func splitPath(path string) (string, string) {
+	i := strings.LastIndexAny(path, ".[")
+	if i < 0 {
+		return path, ""
+	}
+
+	return path[:i], strings.TrimPrefix(path[i:], ".")
+}
+
+// mappingValues returns the key-value pairs of a block mapping node.
+func mappingValues(node ast.Node) []*ast.MappingValueNode {
+	switch n := node.(type) {
+	case *ast.MappingNode:
+		if !n.IsFlowStyle {
+			return n.Values
+		}
+	case *ast.MappingValueNode:
+		return []*ast.MappingValueNode{n}
+	}
+
+	return nil
+}
+
+// splice replaces the source text in r with text.
+func (e *editor) splice(r sourceRange, text string) {
+	from := e.offset(r.line, r.column)
+	to := e.offset(r.endLine, r.endColumn)
+
+	if to < len(e.src) {
+		_, size := utf8.DecodeRune(e.src[to:])
+		to += size
+	}
+
+	e.src = slices.Concat(e.src[:from], []byte(text), e.src[to:])
+}
+
+// lineOffset returns the offset of the start of line, or the length of the
+// source if it's past the last one.
+func (e *editor) lineOffset(line int) int {
+	offset := 0
+
+	for range line - 1 {
+		i := bytes.IndexByte(e.src[offset:], '\n')
+		if i < 0 {
+			return len(e.src)
+		}
+
+		offset += i + 1
+	}
+
+	return offset
+}
+
+// offset returns the offset of the character at line and column.
+func (e *editor) offset(line int, column int) int {
+	offset := e.lineOffset(line)
+
+	for range column - 1 {
+		if offset >= len(e.src) || e.src[offset] == '\n' {
+			break
+		}
+
+		_, size := utf8.DecodeRune(e.src[offset:])
+		offset += size
+	}
+
+	return offset
+}
+
+// onlyIndentBefore returns whether there's just whitespace before column on
+// line.
+func (e *editor) onlyIndentBefore(line int, column int) bool {
+	from := e.lineOffset(line)
+
+	return strings.TrimSpace(string(e.src[from:e.offset(line, column)])) == ""
+}
+
+// formatScalar returns value as a YAML scalar, in the same style as the token
+// it replaces, if any.
+func formatScalar(value string, old *token.Token) string {
+	if old != nil {
+		switch old.Type { //nolint:exhaustive // only quoted styles matter
+		case token.DoubleQuoteType:
+			return fmt.Sprintf("%q", value)
+		case token.SingleQuoteType:
+			return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+		}
+	}
+
+	out, err := yaml.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%q", value)
+	}
+
+	return strings.TrimSuffix(string(out), "\n")
+}
@@ -0,0 +1,107 @@
+package publiccode
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestApplyFixes(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		fixes    []Fix
+		expected string
+	}{
+		{
+			"replace",
+			"intendedAudience:\n  countries:\n    - it # Italy\n    - \"de\"\n  unsupportedCountries: ['us']\n",
+			[]Fix{
+				{Edits: []Edit{{Op: EditReplace, Path: "$.intendedAudience.countries[0]", Value: "IT"}}},
+				{Edits: []Edit{{Op: EditReplace, Path: "$.intendedAudience.countries[1]", Value: "DE"}}},
+				{Edits: []Edit{{Op: EditReplace, Path: "$.intendedAudience.unsupportedCountries[0]", Value: "US"}}},
+			},
+			"intendedAudience:\n  countries:\n    - IT # Italy\n    - \"DE\"\n  unsupportedCountries: ['US']\n",
+		},
+		{
+			"rename",
+			"name: Medusa\n\n# Italian section\nit:\n  conforme: {}\n",
+			[]Fix{{Edits: []Edit{{Op: EditRename, Path: "$.it", Value: "IT"}}}},
+			"name: Medusa\n\n# Italian section\nIT:\n  conforme: {}\n",
+		},
+		{
+			"remove and set",
+			"name: Medusa # the name\norganisation:\n  name: ACME\n\nit:\n  countryExtensionVersion: \"0.2\"\n  riuso:\n    codiceIPA: pcm # iPA\nlegal:\n  license: MIT\n",
+			[]Fix{
+				{Edits: []Edit{{Op: EditRename, Path: "$.it", Value: "IT"}}},
+				{Edits: []Edit{
+					{Op: EditRemove, Path: "$.it.riuso.codiceIPA"},
+					{Op: EditSet, Path: "$.organisation.uri", Value: "urn:x-italian-pa:pcm"},
+				}},
+			},
+			"name: Medusa # the name\norganisation:\n  name: ACME\n  uri: urn:x-italian-pa:pcm\n\nIT:\n  countryExtensionVersion: \"0.2\"\nlegal:\n  license: MIT\n",
+		},
+		{
+			"set missing parent",
+			"name: Medusa\nlegal:\n  license: MIT",
+			[]Fix{{Edits: []Edit{{Op: EditSet, Path: "$.organisation.uri", Value: "urn:x-italian-pa:pcm"}}}},
+			"name: Medusa\nlegal:\n  license: MIT\norganisation:\n  uri: urn:x-italian-pa:pcm\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := ApplyFixes([]byte(test.src), test.fixes)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if string(out) != test.expected {
+				t.Errorf("got:\n%s\nwant:\n%s", out, test.expected)
+			}
+		})
+	}
+}
+
+func TestApplyFixesNotFound(t *testing.T) {
+	_, err := ApplyFixes([]byte("name: Medusa\n"), []Fix{{Edits: []Edit{{Op: EditRemove, Path: "$.it.riuso"}}}})
+	if err == nil {
+		t.Error("expected error for a missing key")
+	}
+}
+
+func TestApplyFixesFromWarnings(t *testing.T) {
+	p, err := NewParser(ParserConfig{DisableNetwork: true, BaseURL: "testdata/v0/valid_with_warnings/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range []string{
+		"testdata/v0/valid_with_warnings/valid_with_lowercase_countries.yml",
+		"testdata/v0/valid_with_warnings/valid_with_country_specific_section_downcase.yml",
+		"testdata/v0/valid_with_warnings/valid_with_IT_riuso_codiceIPA.yml",
+	} {
+		t.Run(file, func(t *testing.T) {
+			src, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = p.ParseStream(bytes.NewReader(src))
+
+			fixes := Fixes(err)
+			if len(fixes) == 0 {
+				t.Fatalf("expected fixes for %v", err)
+			}
+
+			fixed, err := ApplyFixes(src, fixes)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := p.ParseStream(bytes.NewReader(fixed)); err != nil {
+				t.Errorf("unexpected error after the fixes: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package publiccode
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseGitRepo reads the publiccode.yml at the root of the local git
+// repository at repoPath, as it is in the commit ref points to (eg. a tag or
+// a branch), and tries to parse it. The working tree is never used, relative
+// files (eg. logo) are looked up in the tree of the same commit.
+//
+// If ref is empty, the Branch set in ParserConfig is used, or HEAD if that's
+// empty too.
+//
+// It requires the git executable.
+func (p *Parser) ParseGitRepo(repoPath string, ref string) (PublicCode, error) {
+	return p.ParseGitRepoContext(context.Background(), repoPath, ref)
+}
+
+// ParseGitRepoContext is like ParseGitRepo, but the git commands and the
+// external checks are bound to ctx.
+func (p *Parser) ParseGitRepoContext(ctx context.Context, repoPath string, ref string) (PublicCode, error) {
+	ctx, cancel := p.withParseTimeout(ctx)
+	defer cancel()
+
+	if ref == "" {
+		ref = p.branch
+	}
+
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	out, err := runGit(ctx, repoPath, "rev-parse", "--verify", "--quiet", "--end-of-options", ref+"^{commit}")
+	if err != nil {
+		return nil, fmt.Errorf("can't resolve git ref '%s' in '%s': %w", ref, repoPath, err)
+	}
+
+	fsys := &gitTreeFS{ctx: ctx, repo: repoPath, commit: strings.TrimSpace(string(out))}
+
+	content, err := fs.ReadFile(fsys, "publiccode.yml")
+	if err != nil {
+		return nil, fmt.Errorf("can't read publiccode.yml at git ref '%s' in '%s': %w", ref, repoPath, err)
+	}
+
+	// Copy the parser so the tree is used as base for relative files just for
+	// this call.
+	gitParser := *p
+	gitParser.baseFS = fsys
+
+	return gitParser.parseStream(ctx, bytes.NewReader(content), nil)
+}
+
+var errGit = errors.New("git command failed")
+
+// runGit runs git with args in the repository at repo and returns its stdout.
+func runGit(ctx context.Context, repo string, args ...string) ([]byte, error) {
+	return runGitWithInput(ctx, repo, "", args...)
+}
+
+// runGitWithInput is like runGit, but feeds input to git's stdin.
+func runGitWithInput(ctx context.Context, repo string, input string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", repo}, args...)...)
+	cmd.Stdin = strings.NewReader(input)
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", errGit, msg)
+		}
+
+		return nil, fmt.Errorf("%w: %w", errGit, err)
+	}
+
+	return out, nil
+}
+
+// gitTreeFS is an fs.FS with the files in the tree of a commit of a local git
+// repository.
+type gitTreeFS struct {
+	ctx    context.Context //nolint:containedctx // the FS lives as long as a single parse
+	repo   string
+	commit string
+}
+
+// Open implements fs.FS.
+func (g *gitTreeFS) Open(name string) (fs.File, error) {
+	info, err := g.stat("open", name)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		return &gitFile{info: info}, nil
+	}
+
+	content, err := runGit(g.ctx, g.repo, "cat-file", "blob", g.object(name))
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	return &gitFile{info: info, Reader: bytes.NewReader(content)}, nil
+}
+
+// Stat implements fs.StatFS.
+func (g *gitTreeFS) Stat(name string) (fs.FileInfo, error) {
+	return g.stat("stat", name)
+}
+
+func (g *gitTreeFS) stat(op string, name string) (*gitFileInfo, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+
+	// One line with the object type and size, or "<object> missing".
+	out, err := runGitWithInput(g.ctx, g.repo, g.object(name)+"\n", "cat-file", "--batch-check=%(objecttype) %(objectsize)")
+	if err != nil {
+		return nil, &fs.PathError{Op: op, Path: name, Err: err}
+	}
+
+	fields := strings.Fields(string(out))
+	if len(fields) != 2 || fields[1] == "missing" {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+
+	size, _ := strconv.ParseInt(fields[1], 10, 64)
+
+	return &gitFileInfo{name: path.Base(name), size: size, dir: fields[0] == "tree"}, nil
+}
+
+// object returns the git object name of the file name in the commit tree.
+func (g *gitTreeFS) object(name string) string {
+	if name == "." {
+		return g.commit + "^{tree}"
+	}
+
+	return g.commit + ":" + name
+}
+
+type gitFile struct {
+	*bytes.Reader
+
+	info *gitFileInfo
+}
+
+func (f *gitFile) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+
+func (f *gitFile) Read(b []byte) (int, error) {
+	if f.Reader == nil {
+		return 0, &fs.PathError{Op: "read", Path: f.info.name, Err: fs.ErrInvalid}
+	}
+
+	return f.Reader.Read(b) //nolint:wrapcheck // must forward io.EOF unwrapped
+}
+
+func (f *gitFile) Close() error {
+	return nil
+}
+
+var _ io.ReadSeeker = (*gitFile)(nil)
+
+type gitFileInfo struct {
+	name string
+	size int64
+	dir  bool
+}
+
+func (i *gitFileInfo) Name() string { return i.name }
+func (i *gitFileInfo) Size() int64  { return i.size }
+
+func (i *gitFileInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0o555
+	}
+
+	return 0o444
+}
+
+func (i *gitFileInfo) ModTime() time.Time { return time.Time{} }
+func (i *gitFileInfo) IsDir() bool        { return i.dir }
+func (i *gitFileInfo) Sys() any           { return nil }
@@ -0,0 +1,106 @@
+package publiccode
+
+import (
+	"os"
+	"os/exec"
+	"path"
+	"reflect"
+	"testing"
+)
+
+// newGitRepo returns a git repository with files committed and
+// tagged as v1, and a second commit removing removed.
+func newGitRepo(t *testing.T, files map[string][]byte, removed string) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+
+	git := func(args ...string) {
+		t.Helper()
+
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	git("init", "--quiet")
+
+	for name, data := range files {
+		if err := os.MkdirAll(path.Join(dir, path.Dir(name)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path.Join(dir, name), data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git("add", "-A")
+	git("commit", "--quiet", "-m", "first")
+	git("tag", "v1")
+	git("rm", "--quiet", removed)
+	git("commit", "--quiet", "-m", "second")
+
+	return dir
+}
+
+func TestParseGitRepo(t *testing.T) {
+	fsys := mapFSFromDir(t, "testdata/v0/valid/no-network",
+		"valid.yml",
+		"assets/img/logo.png",
+		"assets/img/sshot1.png",
+		"assets/img/sshot2.png",
+		"assets/img/sshot3.png",
+	)
+
+	files := map[string][]byte{"publiccode.yml": fsys["valid.yml"].Data}
+	for name, f := range fsys {
+		if name != "valid.yml" {
+			files[name] = f.Data
+		}
+	}
+
+	repo := newGitRepo(t, files, "assets/img/sshot2.png")
+
+	// The working tree must not be used.
+	if err := os.WriteFile(path.Join(repo, "publiccode.yml"), []byte("invalid"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := NewParser(ParserConfig{DisableNetwork: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := p.ParseGitRepo(repo, "v1"); err != nil {
+		t.Errorf("unexpected error at v1: %v", err)
+	}
+
+	_, err = p.ParseGitRepo(repo, "")
+
+	expected := ValidationResults{
+		ValidationError{Key: "description.en.screenshots[1]", Description: "'assets/img/sshot2.png' is not an image: no such file: assets/img/sshot2.png", Line: 169, Column: 9, EndLine: 169, EndColumn: 29, Code: "PC-INVALID-IMAGE"},
+	}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("unexpected error at HEAD:\n%v\nexpected:\n%v", err, expected)
+	}
+
+	// Branch is used when no ref is passed.
+	p, err = NewParser(ParserConfig{DisableNetwork: true, Branch: "v1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := p.ParseGitRepo(repo, ""); err != nil {
+		t.Errorf("unexpected error with Branch v1: %v", err)
+	}
+
+	if _, err := p.ParseGitRepo(repo, "nonexistent"); err == nil {
+		t.Error("expected error for a nonexistent ref")
+	}
+}
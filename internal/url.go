@@ -2,8 +2,13 @@ package netutil
 
 import (
 	"net/url"
+	"strings"
 )
 
+// FSScheme is the scheme of the URLs of files resolved against an fs.FS
+// instead of the local filesystem or the network.
+const FSScheme = "fs"
+
 // IsValidURL tests a string to determine if it is a well-structured url or not.
 func IsValidURL(toTest string) (bool, *url.URL) {
 	_, err := url.ParseRequestURI(toTest)
@@ -28,8 +33,11 @@ func IsValidURL(toTest string) (bool, *url.URL) {
 }
 
 func DisplayURL(u *url.URL) string {
-	if u.Scheme == "file" {
+	switch u.Scheme {
+	case "file":
 		return u.Path
+	case FSScheme:
+		return strings.TrimPrefix(u.Path, "/")
 	}
 
 	return u.String()
@@ -0,0 +1,71 @@
+package publiccode
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"unicode/utf8"
+)
+
+// ParseJSON is like ParseStream, but in is publiccode data in JSON, with the
+// keys of the json tags of PublicCodeV0 and PublicCodeV1. It goes through the
+// same validation and external checks, and the positions of the
+// ValidationResults are lines and columns in the JSON text.
+//
+// ParseStream and Parse accept JSON too, as it's YAML as well, but without
+// checking it's strictly JSON.
+func (p *Parser) ParseJSON(in io.Reader) (PublicCode, error) {
+	return p.ParseJSONContext(context.Background(), in)
+}
+
+// ParseJSONContext is like ParseJSON, with the context semantics of
+// ParseStreamContext.
+func (p *Parser) ParseJSONContext(ctx context.Context, in io.Reader) (PublicCode, error) {
+	ctx, cancel := p.withParseTimeout(ctx)
+	defer cancel()
+
+	b, err := p.readStream(in)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.checkJSON(b); err != nil {
+		return nil, err
+	}
+
+	return p.parseStream(ctx, bytes.NewReader(b), nil)
+}
+
+// checkJSON returns the syntax error in the JSON b, if any. JSON is YAML as
+// well, but YAML syntax in it (eg. unquoted keys) would pass otherwise.
+func (p *Parser) checkJSON(b []byte) error {
+	if json.Valid(b) {
+		return nil
+	}
+
+	var v any
+
+	err := json.Unmarshal(b, &v)
+	ve := ValidationError{Description: p.message("invalid_json", err), Code: CheckInvalidYAML.Code()}
+
+	var se *json.SyntaxError
+	if errors.As(err, &se) {
+		// The offset is right after the offending character.
+		ve.Line, ve.Column = offsetPosition(b, int(max(se.Offset-1, 0)))
+	}
+
+	return ValidationResults{ve}
+}
+
+// offsetPosition returns the line and column of the byte offset in b.
+func offsetPosition(b []byte, offset int) (int, int) {
+	offset = min(offset, len(b))
+
+	before := b[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := utf8.RuneCount(before[bytes.LastIndexByte(before, '\n')+1:]) + 1
+
+	return line, column
+}
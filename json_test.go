@@ -0,0 +1,142 @@
+package publiccode
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseJSON(t *testing.T) {
+	p, err := NewParser(ParserConfig{DisableExternalChecks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pc, err := p.Parse("testdata/v0/valid/valid.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// it is just a copy of IT.
+	v0 := pc.(PublicCodeV0)
+	v0.It = nil
+
+	b, err := json.MarshalIndent(v0, "", "\t")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fromJSON, err := p.ParseJSON(bytes.NewReader(b))
+	if hasErrors(err) {
+		t.Fatalf("unexpected errors: %v", err)
+	}
+
+	// ParseStream detects JSON too.
+	fromStream, _ := p.ParseStream(bytes.NewReader(b))
+	if !reflect.DeepEqual(fromJSON, fromStream) {
+		t.Errorf("different results from ParseJSON and ParseStream:\n%v\n%v", fromJSON, fromStream)
+	}
+
+	var unmarshaled PublicCodeV0
+	if err := json.Unmarshal(b, &unmarshaled); err != nil {
+		t.Fatal(err)
+	}
+
+	if unmarshaled.URL.String() != v0.URL.String() || len(unmarshaled.IsBasedOn) != len(v0.IsBasedOn) {
+		t.Errorf("wrong URLs unmarshaling JSON: %v", unmarshaled)
+	}
+}
+
+// TestParseStreamYAMLFlowMapping checks that a YAML flow mapping, which
+// starts with '{' as JSON does, isn't parsed as JSON.
+func TestParseStreamYAMLFlowMapping(t *testing.T) {
+	p, err := NewParser(ParserConfig{DisableExternalChecks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = p.ParseStream(strings.NewReader("{publiccodeYmlVersion: '0.4', name: x} # comment\n"))
+
+	var results ValidationResults
+	if !errors.As(err, &results) {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	for _, res := range results {
+		if checkOf(res) == CheckInvalidYAML {
+			t.Errorf("unexpected YAML error: %v", res)
+		}
+	}
+
+	if !slices.ContainsFunc(results, func(res error) bool {
+		return strings.Contains(res.Error(), "url is a required field")
+	}) {
+		t.Errorf("missing validation errors: %v", results)
+	}
+}
+
+func TestParseJSONErrors(t *testing.T) {
+	p, err := NewParser(ParserConfig{DisableExternalChecks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []struct {
+		src      string
+		expected ValidationError
+	}{
+		// YAML, but not JSON.
+		{
+			"{\n  \"publiccodeYmlVersion\": \"0\",\n  name: Medusa\n}\n",
+			ValidationError{
+				Description: "invalid JSON: invalid character 'n' looking for beginning of object key string",
+				Line:        3, Column: 3, Code: "PC-INVALID-YAML",
+			},
+		},
+		{
+			"{\n  \"publiccodeYmlVersion\": \"0\",\n}\n",
+			ValidationError{
+				Description: "invalid JSON: invalid character '}' looking for beginning of object key string",
+				Line:        3, Column: 1, Code: "PC-INVALID-YAML",
+			},
+		},
+		// Positions of the validation errors are in the JSON text.
+		{
+			"{\n  \"publiccodeYmlVersion\": \"0\",\n  \"developmentStatus\": \"ready\"\n}\n",
+			ValidationError{
+				Key: "developmentStatus",
+				Description: "developmentStatus must be one of the following: " +
+					"\"concept\", \"development\", \"beta\", \"stable\" or \"obsolete\"",
+				Line: 3, Column: 3, EndLine: 3, EndColumn: 30, Code: "PC-INVALID-VALUE",
+			},
+		},
+	}
+
+	for _, test := range testcases {
+		_, err := p.ParseJSON(strings.NewReader(test.src))
+
+		var results ValidationResults
+		if !errors.As(err, &results) {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		found := false
+		for _, res := range results {
+			if ve, ok := res.(ValidationError); ok && reflect.DeepEqual(ve, test.expected) {
+				found = true
+			}
+		}
+
+		if !found {
+			t.Errorf("%q: %#v not in %v", test.src, test.expected, results)
+		}
+	}
+
+	if _, err := p.ParseJSON(strings.NewReader("publiccodeYmlVersion: \"0\"\n")); !hasErrors(err) {
+		t.Error("expected an error parsing YAML as JSON")
+	}
+}
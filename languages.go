@@ -0,0 +1,104 @@
+package publiccode
+
+import (
+	"maps"
+	"slices"
+
+	"golang.org/x/text/language"
+)
+
+// BestDescription returns the description best matching acceptLanguage,
+// the value of an Accept-Language HTTP header (eg. "it-IT,it;q=0.9,en;q=0.8"),
+// along with the tag of the description matched. See DescriptionFor for the
+// fallbacks.
+func (p PublicCodeV0) BestDescription(acceptLanguage string, fallback language.Tag) (Description, language.Tag, bool) {
+	return matchDescription(p, slices.Collect(maps.Keys(p.Description)), parseAcceptLanguage(acceptLanguage), fallback)
+}
+
+// DescriptionFor returns the description best matching tag (eg. it for
+// it-IT), along with the tag of the description matched.
+//
+// If none matches, it falls back to the description in fallback (eg.
+// language.English, or language.Und for none), then to the first of
+// localisation.availableLanguages having one and then to the first one in
+// alphabetical order. It returns false if there are no descriptions.
+func (p PublicCodeV0) DescriptionFor(tag language.Tag, fallback language.Tag) (Description, language.Tag, bool) {
+	return matchDescription(p, slices.Collect(maps.Keys(p.Description)), []language.Tag{tag}, fallback)
+}
+
+// BestDescription is like PublicCodeV0.BestDescription.
+func (p PublicCodeV1) BestDescription(acceptLanguage string, fallback language.Tag) (Description, language.Tag, bool) {
+	return matchDescription(p, slices.Collect(maps.Keys(p.Description)), parseAcceptLanguage(acceptLanguage), fallback)
+}
+
+// DescriptionFor is like PublicCodeV0.DescriptionFor.
+func (p PublicCodeV1) DescriptionFor(tag language.Tag, fallback language.Tag) (Description, language.Tag, bool) {
+	return matchDescription(p, slices.Collect(maps.Keys(p.Description)), []language.Tag{tag}, fallback)
+}
+
+// parseAcceptLanguage returns the tags in acceptLanguage, by preference. An
+// invalid header is the same as an empty one.
+func parseAcceptLanguage(acceptLanguage string) []language.Tag {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil {
+		return nil
+	}
+
+	return tags
+}
+
+// matchDescription returns the description of publiccode, in one of langs,
+// best matching tags, with the fallbacks of DescriptionFor.
+func matchDescription(
+	publiccode PublicCode, langs []string, tags []language.Tag, fallback language.Tag,
+) (Description, language.Tag, bool) {
+	slices.Sort(langs)
+
+	var (
+		keys      []string
+		supported []language.Tag
+	)
+
+	// The keys are validated, but publiccode could be invalid.
+	for _, lang := range langs {
+		if tag, err := language.Parse(lang); err == nil {
+			keys = append(keys, lang)
+			supported = append(supported, tag)
+		}
+	}
+
+	if len(supported) == 0 {
+		return Description{}, language.Und, false
+	}
+
+	preferences := [][]language.Tag{tags}
+	if fallback != language.Und {
+		preferences = append(preferences, []language.Tag{fallback})
+	}
+
+	for _, lang := range publiccode.GetLanguages() {
+		if tag, err := language.Parse(lang); err == nil {
+			preferences = append(preferences, []language.Tag{tag})
+		}
+	}
+
+	matcher := language.NewMatcher(supported)
+
+	index := 0
+
+	for _, tags := range preferences {
+		if len(tags) == 0 {
+			continue
+		}
+
+		if _, i, confidence := matcher.Match(tags...); confidence != language.No {
+			index = i
+
+			break
+		}
+	}
+
+	desc, _ := publiccode.GetDescription(keys[index])
+
+	return desc, supported[index], true
+}
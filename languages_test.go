@@ -0,0 +1,68 @@
+package publiccode
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestBestDescription(t *testing.T) {
+	pc := PublicCodeV0{
+		Description: map[string]DescV0{
+			"de": {ShortDescription: "Deutsch"},
+			"en": {ShortDescription: "English"},
+			"it": {ShortDescription: "Italiano"},
+			"pt": {ShortDescription: "Português"},
+		},
+		Localisation: NewLocalisationV0(true, "fr", "pt", "de"),
+	}
+
+	tests := []struct {
+		acceptLanguage string
+		fallback       language.Tag
+		expected       string
+	}{
+		{"it-IT,it;q=0.9,en;q=0.8", language.English, "it"},
+		{"it-IT", language.English, "it"},
+		{"fr-CH, fr;q=0.9, de;q=0.7", language.English, "de"},
+		{"pt-BR", language.English, "pt"},
+		{"fr", language.English, "en"},
+		{"fr", language.German, "de"},
+		{"fr", language.Und, "pt"},
+		{"", language.English, "en"},
+		{"invalid;q=x", language.English, "en"},
+	}
+
+	for _, test := range tests {
+		desc, tag, ok := pc.BestDescription(test.acceptLanguage, test.fallback)
+		if !ok || tag.String() != test.expected || desc.ShortDescription != pc.Description[test.expected].ShortDescription {
+			t.Errorf("%q (fallback %s): got %s %+v, %v, expected %s",
+				test.acceptLanguage, test.fallback, tag, desc, ok, test.expected)
+		}
+	}
+}
+
+func TestDescriptionFor(t *testing.T) {
+	pc := &PublicCodeV1{
+		Description: map[string]DescV1{
+			"es": {ShortDescription: "Español"},
+			"it": {ShortDescription: "Italiano"},
+		},
+		Localisation: NewLocalisationV1(true, "en"),
+	}
+
+	if desc, tag, ok := pc.DescriptionFor(language.MustParse("it-IT"), language.English); !ok || tag != language.Italian ||
+		desc.ShortDescription != "Italiano" {
+		t.Errorf("got %s %+v, %v", tag, desc, ok)
+	}
+
+	// Neither the fallback nor the available languages have a description.
+	if desc, tag, ok := pc.DescriptionFor(language.French, language.English); !ok || tag != language.Spanish ||
+		desc.ShortDescription != "Español" {
+		t.Errorf("got %s %+v, %v", tag, desc, ok)
+	}
+
+	if _, tag, ok := (PublicCodeV1{}).DescriptionFor(language.Italian, language.English); ok || tag != language.Und {
+		t.Errorf("unexpected match %s without descriptions", tag)
+	}
+}
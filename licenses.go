@@ -0,0 +1,511 @@
+package publiccode
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/github/go-spdx/v2/spdxexp"
+
+	"github.com/italia/publiccode-parser-go/v5/data"
+)
+
+// LicenseCategory is the kind of a license, by the obligations it puts on
+// derived works.
+type LicenseCategory string
+
+const (
+	// LicensePermissive is a license with minimal requirements (eg. MIT).
+	LicensePermissive LicenseCategory = "permissive"
+	// LicenseWeakCopyleft is a license requiring the changes to the licensed
+	// files to be shared under the same license, but not the whole work
+	// (eg. LGPL-3.0-only).
+	LicenseWeakCopyleft LicenseCategory = "weak-copyleft"
+	// LicenseCopyleft is a license requiring the derived works to be shared
+	// under the same license (eg. AGPL-3.0-or-later).
+	LicenseCopyleft LicenseCategory = "copyleft"
+)
+
+// LicenseExpression is a parsed SPDX license expression, like legal.license.
+type LicenseExpression struct {
+	Expression string `json:"expression"`
+
+	// Licenses are the licenses in Expression, in order and without
+	// duplicates.
+	Licenses []License `json:"licenses"`
+
+	// OpenSource is whether the software can be used under open source
+	// licenses only, ie. whether at least one choice of the OR alternatives
+	// has all its licenses approved by the OSI or free for the FSF.
+	OpenSource bool `json:"openSource"`
+}
+
+// License is a license in a LicenseExpression, with its classification from
+// the SPDX license list. Licenses not in the list (eg. LicenseRef-custom) only
+// have their ID.
+type License struct {
+	// ID is the SPDX identifier (eg. "GPL-3.0-only"), as in the SPDX list.
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+
+	// OrLater is whether the license is followed by "+" (eg. "Apache-2.0+").
+	OrLater bool `json:"orLater,omitempty"`
+
+	// Exception is the exception of the license, after WITH.
+	Exception string `json:"exception,omitempty"`
+
+	OSIApproved bool `json:"osiApproved"`
+	FSFLibre    bool `json:"fsfLibre"`
+
+	// Deprecated is whether ID is deprecated, with the identifier replacing
+	// it, if any, in Replacement.
+	Deprecated  bool   `json:"deprecated,omitempty"`
+	Replacement string `json:"replacement,omitempty"`
+
+	// Category is the kind of license, "" if unknown.
+	Category LicenseCategory `json:"category,omitempty"`
+}
+
+// String returns l as in an SPDX expression (eg. "GPL-2.0-only WITH
+// Classpath-exception-2.0").
+func (l License) String() string {
+	s := l.ID
+	if l.OrLater {
+		s += "+"
+	}
+
+	if l.Exception != "" {
+		s += " WITH " + l.Exception
+	}
+
+	return s
+}
+
+// spdxLicense is a license in data.SpdxLicenses.
+type spdxLicense struct {
+	ID          string `json:"licenseId"`
+	Name        string `json:"name"`
+	OSIApproved bool   `json:"isOsiApproved"`
+	FSFLibre    bool   `json:"isFsfLibre"`
+	Deprecated  bool   `json:"isDeprecatedLicenseId"`
+}
+
+// spdxLicenses are the licenses in the SPDX license list, by uppercase ID.
+var spdxLicenses map[string]spdxLicense
+
+// licenseCategories are the categories of the common licenses, by prefix of
+// their ID. The first matching prefix wins.
+var licenseCategories = []struct {
+	prefix   string
+	category LicenseCategory
+}{
+	{"AGPL-", LicenseCopyleft},
+	{"GPL-", LicenseCopyleft},
+	{"EUPL-", LicenseCopyleft},
+	{"OSL-", LicenseCopyleft},
+	{"CECILL-B", LicensePermissive},
+	{"CECILL-C", LicenseWeakCopyleft},
+	{"CECILL-", LicenseCopyleft},
+	{"CC-BY-SA-", LicenseCopyleft},
+	{"CC-BY-NC", ""},
+	{"CC-BY-ND", ""},
+	{"CC-BY-", LicensePermissive},
+	{"ODbL-", LicenseCopyleft},
+	{"RPL-", LicenseCopyleft},
+	{"Sleepycat", LicenseCopyleft},
+	{"LGPL-", LicenseWeakCopyleft},
+	{"MPL-", LicenseWeakCopyleft},
+	{"EPL-", LicenseWeakCopyleft},
+	{"CDDL-", LicenseWeakCopyleft},
+	{"CPL-", LicenseWeakCopyleft},
+	{"MS-RL", LicenseWeakCopyleft},
+	{"APSL-", LicenseWeakCopyleft},
+	{"0BSD", LicensePermissive},
+	{"AFL-", LicensePermissive},
+	{"Apache-", LicensePermissive},
+	{"Artistic-2.0", LicensePermissive},
+	{"BSD-", LicensePermissive},
+	{"BSL-1.0", LicensePermissive},
+	{"CC0-", LicensePermissive},
+	{"ECL-", LicensePermissive},
+	{"EFL-", LicensePermissive},
+	{"ISC", LicensePermissive},
+	{"MIT", LicensePermissive},
+	{"MS-PL", LicensePermissive},
+	{"NCSA", LicensePermissive},
+	{"PostgreSQL", LicensePermissive},
+	{"PSF-", LicensePermissive},
+	{"Python-", LicensePermissive},
+	{"Unlicense", LicensePermissive},
+	{"UPL-", LicensePermissive},
+	{"W3C", LicensePermissive},
+	{"X11", LicensePermissive},
+	{"Zlib", LicensePermissive},
+	{"ZPL-", LicensePermissive},
+}
+
+var errInvalidLicense = errors.New("invalid SPDX license expression")
+
+func init() {
+	var list struct {
+		Licenses []spdxLicense `json:"licenses"`
+	}
+
+	if err := json.Unmarshal(data.SpdxLicenses, &list); err != nil {
+		panic("failed to parse the SPDX license list: " + err.Error()) //nolint:forbidigo,lll // embedded at compile time, a failure here is a programming error
+	}
+
+	spdxLicenses = make(map[string]spdxLicense, len(list.Licenses))
+	for _, l := range list.Licenses {
+		spdxLicenses[strings.ToUpper(l.ID)] = l
+	}
+}
+
+// ParseLicenseExpression parses the SPDX license expression (eg. "MIT OR
+// Apache-2.0"), classifying its licenses with the SPDX license list embedded
+// in the parser.
+func ParseLicenseExpression(expression string) (LicenseExpression, error) {
+	tree, err := parseLicenseTree(expression)
+	if err != nil {
+		return LicenseExpression{}, err
+	}
+
+	expr := LicenseExpression{Expression: expression, OpenSource: tree.openSource()}
+
+	for _, leaf := range tree.leaves() {
+		if !containsLicense(expr.Licenses, leaf.license) {
+			expr.Licenses = append(expr.Licenses, leaf.license)
+		}
+	}
+
+	return expr, nil
+}
+
+func containsLicense(licenses []License, l License) bool {
+	for _, license := range licenses {
+		if license.String() == l.String() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetLicenseExpression returns legal.license parsed as an SPDX license
+// expression, with the details of each license.
+func (p PublicCodeV0) GetLicenseExpression() (LicenseExpression, error) {
+	return ParseLicenseExpression(p.Legal.License)
+}
+
+// GetLicenseExpression is like PublicCodeV0.GetLicenseExpression.
+func (p PublicCodeV1) GetLicenseExpression() (LicenseExpression, error) {
+	return ParseLicenseExpression(p.Legal.License)
+}
+
+// licenseNode is a node of a parsed SPDX expression: a license or the AND or
+// OR of two nodes.
+type licenseNode struct {
+	op          string
+	left, right *licenseNode
+
+	license License
+
+	// start and end are the offsets of the license in the expression,
+	// without the exception.
+	start, end int
+}
+
+func (n *licenseNode) openSource() bool {
+	switch n.op {
+	case "AND":
+		return n.left.openSource() && n.right.openSource()
+	case "OR":
+		return n.left.openSource() || n.right.openSource()
+	}
+
+	return n.license.OSIApproved || n.license.FSFLibre
+}
+
+func (n *licenseNode) leaves() []*licenseNode {
+	if n.op == "" {
+		return []*licenseNode{n}
+	}
+
+	return append(n.left.leaves(), n.right.leaves()...)
+}
+
+// licenseToken is a token of an SPDX expression, at offset start.
+type licenseToken struct {
+	text  string
+	start int
+}
+
+// licenseParser parses an SPDX expression, already validated, with WITH
+// binding tighter than AND and AND tighter than OR.
+type licenseParser struct {
+	tokens []licenseToken
+	pos    int
+}
+
+// parseLicenseTree returns the tree of the SPDX expression.
+func parseLicenseTree(expression string) (*licenseNode, error) {
+	if valid, _ := spdxexp.ValidateLicenses([]string{expression}); !valid {
+		return nil, fmt.Errorf("%w: '%s'", errInvalidLicense, expression)
+	}
+
+	p := licenseParser{tokens: tokenizeLicense(expression)}
+
+	tree, err := p.parseOr()
+	if err == nil && p.pos < len(p.tokens) {
+		err = fmt.Errorf("%w: unexpected '%s'", errInvalidLicense, p.tokens[p.pos].text)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tree, nil
+}
+
+func tokenizeLicense(expression string) []licenseToken {
+	var tokens []licenseToken
+
+	for i := 0; i < len(expression); {
+		switch c := expression[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, licenseToken{string(c), i})
+			i++
+		default:
+			start := i
+			for i < len(expression) && !strings.ContainsRune(" \t\n\r()", rune(expression[i])) {
+				i++
+			}
+
+			tokens = append(tokens, licenseToken{expression[start:i], start})
+		}
+	}
+
+	return tokens
+}
+
+func (p *licenseParser) next() (licenseToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return licenseToken{}, false
+	}
+
+	return p.tokens[p.pos], true
+}
+
+// isOperator returns whether the next token is op, in any case.
+func (p *licenseParser) isOperator(op string) bool {
+	t, ok := p.next()
+
+	return ok && strings.EqualFold(t.text, op)
+}
+
+func (p *licenseParser) parseOr() (*licenseNode, error) {
+	return p.parseBinary("OR", p.parseAnd)
+}
+
+func (p *licenseParser) parseAnd() (*licenseNode, error) {
+	return p.parseBinary("AND", p.parseLicense)
+}
+
+func (p *licenseParser) parseBinary(op string, operand func() (*licenseNode, error)) (*licenseNode, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isOperator(op) {
+		p.pos++
+
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &licenseNode{op: op, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *licenseParser) parseLicense() (*licenseNode, error) {
+	t, ok := p.next()
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected end", errInvalidLicense)
+	}
+
+	p.pos++
+
+	if t.text == "(" {
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if t, ok := p.next(); !ok || t.text != ")" {
+			return nil, fmt.Errorf("%w: missing ')'", errInvalidLicense)
+		}
+
+		p.pos++
+
+		return node, nil
+	}
+
+	node := &licenseNode{license: lookupLicense(t.text), start: t.start, end: t.start + len(t.text)}
+
+	if p.isOperator("WITH") {
+		p.pos++
+
+		exception, ok := p.next()
+		if !ok {
+			return nil, fmt.Errorf("%w: missing exception after WITH", errInvalidLicense)
+		}
+
+		p.pos++
+
+		node.license.Exception = exception.text
+	}
+
+	return node, nil
+}
+
+// lookupLicense returns the License with id, with a trailing "+" if
+// OrLater, from the SPDX license list.
+func lookupLicense(id string) License {
+	l, ok := spdxLicenses[strings.ToUpper(id)]
+
+	// "GPL-3.0+" is a deprecated ID, "Apache-2.0+" is Apache-2.0 or later.
+	orLater := false
+	if !ok && strings.HasSuffix(id, "+") {
+		l, ok = spdxLicenses[strings.ToUpper(strings.TrimSuffix(id, "+"))]
+		orLater = true
+	}
+
+	if !ok {
+		return License{ID: id}
+	}
+
+	license := License{
+		ID:          l.ID,
+		Name:        l.Name,
+		OrLater:     orLater,
+		OSIApproved: l.OSIApproved,
+		FSFLibre:    l.FSFLibre,
+		Deprecated:  l.Deprecated,
+		Category:    categoryOf(l.ID),
+	}
+
+	if l.Deprecated {
+		license.Replacement = replacementOf(l, orLater)
+
+		// The list doesn't classify the deprecated IDs of a license with an
+		// exception (eg. GPL-2.0-with-classpath-exception): the exception
+		// only grants more permissions, they're free as their license.
+		if base, _, ok := strings.Cut(l.ID, "-with-"); ok {
+			if b, ok := spdxLicenses[strings.ToUpper(base)]; ok {
+				license.OSIApproved, license.FSFLibre = b.OSIApproved, b.FSFLibre
+			}
+		}
+	}
+
+	return license
+}
+
+// replacementOf returns the ID replacing the deprecated license l, followed
+// by "+" if orLater: the one with the same name (eg. GPL-3.0-only for
+// GPL-3.0) or, failing that, its "-only" or "-or-later" version. It returns
+// "" if there's none.
+func replacementOf(l spdxLicense, orLater bool) string {
+	if orLater {
+		if later, ok := spdxLicenses[strings.ToUpper(l.ID+"-or-later")]; ok && !later.Deprecated {
+			return later.ID
+		}
+
+		return ""
+	}
+
+	for _, other := range spdxLicenses {
+		if !other.Deprecated && other.Name == l.Name {
+			return other.ID
+		}
+	}
+
+	if only, ok := spdxLicenses[strings.ToUpper(l.ID+"-only")]; ok && !only.Deprecated {
+		return only.ID
+	}
+
+	return ""
+}
+
+func categoryOf(id string) LicenseCategory {
+	for _, c := range licenseCategories {
+		if strings.HasPrefix(id, c.prefix) {
+			return c.category
+		}
+	}
+
+	return ""
+}
+
+// licenseWarnings returns the warnings on the license expression in
+// legal.license: deprecated IDs and licenses that aren't open source.
+func (p *Parser) licenseWarnings(expression string) ValidationResults {
+	tree, err := parseLicenseTree(expression)
+	if err != nil {
+		// Already reported by is_spdx_expression.
+		return nil
+	}
+
+	var (
+		vr    ValidationResults
+		fixed strings.Builder
+		last  int
+		seen  = map[string]bool{}
+	)
+
+	// The expression with the deprecated IDs replaced, for the Fix.
+	for _, leaf := range tree.leaves() {
+		if leaf.license.Replacement != "" {
+			fixed.WriteString(expression[last:leaf.start] + leaf.license.Replacement)
+			last = leaf.end
+		}
+	}
+
+	fixed.WriteString(expression[last:])
+
+	for _, leaf := range tree.leaves() {
+		l := leaf.license
+		if !l.Deprecated || seen[l.ID] {
+			continue
+		}
+
+		seen[l.ID] = true
+
+		warn := ValidationWarning{
+			Key:         "legal.license",
+			Description: p.message("deprecated_license", l.ID),
+		}
+
+		if l.Replacement != "" {
+			warn.Description = p.message("deprecated_license_use", l.ID, l.Replacement)
+			warn.Fix = &Fix{Edits: []Edit{{Op: EditReplace, Path: "$.legal.license", Value: fixed.String()}}}
+		}
+
+		vr = append(vr, withCheck(CheckDeprecatedLicense, warn))
+	}
+
+	if !tree.openSource() {
+		vr = append(vr, withCheck(CheckNonOpenSourceLicense, ValidationWarning{
+			Key:         "legal.license",
+			Description: p.message("non_open_source_license", expression),
+		}))
+	}
+
+	return vr
+}
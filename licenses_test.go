@@ -0,0 +1,173 @@
+package publiccode
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseLicenseExpression(t *testing.T) {
+	tests := []struct {
+		expression string
+		licenses   []License
+		openSource bool
+	}{
+		{
+			"AGPL-3.0-or-later",
+			[]License{{
+				ID: "AGPL-3.0-or-later", Name: "GNU Affero General Public License v3.0 or later",
+				OSIApproved: true, FSFLibre: true, Category: LicenseCopyleft,
+			}},
+			true,
+		},
+		{
+			"(mit OR Apache-2.0) AND LGPL-2.1+",
+			[]License{
+				{ID: "MIT", Name: "MIT License", OSIApproved: true, FSFLibre: true, Category: LicensePermissive},
+				{ID: "Apache-2.0", Name: "Apache License 2.0", OSIApproved: true, FSFLibre: true, Category: LicensePermissive},
+				{
+					ID: "LGPL-2.1+", Name: "GNU Lesser General Public License v2.1 or later",
+					OSIApproved: true, FSFLibre: true, Deprecated: true, Replacement: "LGPL-2.1-or-later",
+					Category: LicenseWeakCopyleft,
+				},
+			},
+			true,
+		},
+		{
+			"GPL-2.0-only WITH Classpath-exception-2.0 OR GPL-2.0-only",
+			[]License{
+				{
+					ID: "GPL-2.0-only", Name: "GNU General Public License v2.0 only", Exception: "Classpath-exception-2.0",
+					OSIApproved: true, FSFLibre: true, Category: LicenseCopyleft,
+				},
+				{
+					ID: "GPL-2.0-only", Name: "GNU General Public License v2.0 only",
+					OSIApproved: true, FSFLibre: true, Category: LicenseCopyleft,
+				},
+			},
+			true,
+		},
+		{
+			"AGPL-3.0+",
+			[]License{{
+				ID: "AGPL-3.0", Name: "GNU Affero General Public License v3.0", OrLater: true,
+				OSIApproved: true, FSFLibre: true, Deprecated: true, Replacement: "AGPL-3.0-or-later",
+				Category: LicenseCopyleft,
+			}},
+			true,
+		},
+		{
+			"MIT AND LicenseRef-proprietary",
+			[]License{
+				{ID: "MIT", Name: "MIT License", OSIApproved: true, FSFLibre: true, Category: LicensePermissive},
+				{ID: "LicenseRef-proprietary"},
+			},
+			false,
+		},
+		{
+			"CC-BY-NC-4.0 OR EUPL-1.2",
+			[]License{
+				{ID: "CC-BY-NC-4.0", Name: "Creative Commons Attribution Non Commercial 4.0 International"},
+				{
+					ID: "EUPL-1.2", Name: "European Union Public License 1.2",
+					OSIApproved: true, FSFLibre: true, Category: LicenseCopyleft,
+				},
+			},
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		expr, err := ParseLicenseExpression(test.expression)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.expression, err)
+
+			continue
+		}
+
+		if !reflect.DeepEqual(expr.Licenses, test.licenses) || expr.OpenSource != test.openSource {
+			t.Errorf("%s: got %+v, %v\nexpected %+v, %v",
+				test.expression, expr.Licenses, expr.OpenSource, test.licenses, test.openSource)
+		}
+	}
+
+	if _, err := ParseLicenseExpression("Invalid License"); !errors.Is(err, errInvalidLicense) {
+		t.Errorf("expected errInvalidLicense, got %v", err)
+	}
+}
+
+func TestLicenseWarnings(t *testing.T) {
+	src, err := os.ReadFile("testdata/v0/valid/valid.minimal.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := NewParser(ParserConfig{DisableExternalChecks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		license  string
+		expected []string
+		fixed    string
+	}{
+		{"AGPL-3.0-or-later", nil, ""},
+		{
+			"GPL-3.0 OR (MIT AND GPL-3.0)",
+			[]string{"legal.license: 'GPL-3.0' is a DEPRECATED SPDX license identifier. Use 'GPL-3.0-only' instead"},
+			"GPL-3.0-only OR (MIT AND GPL-3.0-only)",
+		},
+		{
+			"GPL-2.0-with-classpath-exception",
+			[]string{"legal.license: 'GPL-2.0-with-classpath-exception' is a DEPRECATED SPDX license identifier"},
+			"",
+		},
+		{
+			"LicenseRef-proprietary",
+			[]string{"legal.license: 'LicenseRef-proprietary' is not an open source license: it's neither OSI-approved nor FSF-free"},
+			"",
+		},
+	}
+
+	for _, test := range tests {
+		yml := strings.Replace(string(src), "license: AGPL-3.0-or-later", "license: "+test.license, 1)
+
+		pc, err := p.ParseStream(strings.NewReader(yml))
+		if pc == nil {
+			t.Fatalf("%s: %v", test.license, err)
+		}
+
+		var got []string
+
+		var vr ValidationResults
+		if errors.As(err, &vr) {
+			for _, res := range vr {
+				var warn ValidationWarning
+				if !errors.As(res, &warn) {
+					t.Errorf("%s: unexpected error %v", test.license, res)
+
+					continue
+				}
+
+				got = append(got, warn.Key+": "+warn.Description)
+			}
+		}
+
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("%s: got %q, expected %q", test.license, got, test.expected)
+		}
+
+		fixed, err := ApplyFixes([]byte(yml), Fixes(err))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if test.fixed != "" && !bytes.Contains(fixed, []byte("license: "+test.fixed+"\n")) {
+			t.Errorf("%s: unexpected fix:\n%s", test.license, fixed)
+		}
+	}
+}
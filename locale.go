@@ -0,0 +1,213 @@
+package publiccode
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/it"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	it_translations "github.com/go-playground/validator/v10/translations/it"
+
+	publiccodeValidator "github.com/italia/publiccode-parser-go/v5/validators"
+)
+
+// DefaultLocale is the locale of the diagnostics when ParserConfig.Locale is
+// not set.
+const DefaultLocale = "en"
+
+// Catalogue translates the descriptions of the diagnostics into a language.
+//
+// It maps the ID of each message to its translation, where "{0}", "{1}", ...
+// stand for the arguments, in the same order as in the English message. The
+// IDs are the ones in catalogues/en.json for the messages of the parser (eg.
+// "unknown_field" for "unknown field \"{0}\"{1}"), and the validator tags for
+// the messages of the validation (eg. "required" for "{0} is a required field").
+//
+// Messages not in the Catalogue are left in English.
+type Catalogue map[string]string
+
+// The catalogues of the messages of the parser shipped with the library, one
+// JSON Catalogue per locale (eg. "it.json").
+//
+//go:embed catalogues/*.json
+var builtinCatalogues embed.FS
+
+// builtinLocales are the locales shipped with the library, with the
+// registration of the translations of the validator/v10 messages in each one.
+var builtinLocales = []struct {
+	locale   locales.Translator
+	register func(*validator.Validate, ut.Translator) error
+}{
+	{en.New(), en_translations.RegisterDefaultTranslations},
+	{it.New(), it_translations.RegisterDefaultTranslations},
+}
+
+var (
+	translatorsMu sync.RWMutex
+	translators   = map[string]ut.Translator{}
+
+	// sharedTrans is the translator of DefaultLocale, the one for the messages
+	// missing in the other locales.
+	sharedTrans ut.Translator
+)
+
+var rePlaceholder = regexp.MustCompile(`\{\d+\}`)
+
+func init() {
+	for _, builtin := range builtinLocales {
+		name := builtin.locale.Locale()
+
+		data, err := builtinCatalogues.ReadFile("catalogues/" + name + ".json")
+		if err != nil {
+			panic(err) //nolint:forbidigo // programming error caught at runtime, it's right to panic
+		}
+
+		var catalogue Catalogue
+		if err := json.Unmarshal(data, &catalogue); err != nil {
+			panic(fmt.Sprintf("invalid catalogue %s: %s", name, err)) //nolint:forbidigo // ditto
+		}
+
+		trans, err := newTranslator(builtin.locale, builtin.register, catalogue)
+		if err != nil {
+			panic(fmt.Sprintf("locale '%s': %s", name, err)) //nolint:forbidigo // ditto
+		}
+
+		translators[name] = trans
+	}
+
+	sharedTrans = translators[DefaultLocale]
+}
+
+// newTranslator returns the translator for locale, with the validator/v10
+// messages registered by register, the messages of the validators of this
+// library and catalogue.
+func newTranslator(
+	locale locales.Translator, register func(*validator.Validate, ut.Translator) error, catalogue Catalogue,
+) (ut.Translator, error) {
+	trans, _ := ut.New(locale).GetTranslator(locale.Locale())
+
+	if err := register(sharedValidate, trans); err != nil {
+		return nil, fmt.Errorf("registering the validator messages: %w", err)
+	}
+
+	if err := publiccodeValidator.RegisterLocalErrorMessages(sharedValidate, trans); err != nil {
+		return nil, fmt.Errorf("registering the validator messages: %w", err)
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(catalogue)) {
+		if err := trans.Add(id, catalogue[id], true); err != nil {
+			return nil, fmt.Errorf("message '%s': %w", id, err)
+		}
+	}
+
+	return trans, nil
+}
+
+// RegisterLocale registers the Catalogue for locale, a BCP 47 language tag
+// (eg. "fr"), so that Parsers can be created with it as ParserConfig.Locale.
+// It replaces the Catalogue already registered for locale, if any.
+//
+// It must not be called while parsing, eg. call it in an init function.
+func RegisterLocale(locale string, catalogue Catalogue) error {
+	if locale == "" {
+		return errors.New("empty locale") //nolint:err113 // not worth a sentinel error
+	}
+
+	for id, translation := range catalogue {
+		english, err := sharedTrans.T(id, placeholders...)
+		if err != nil {
+			return fmt.Errorf("locale '%s': unknown message '%s'", locale, id) //nolint:err113 // dynamic value
+		}
+
+		got, want := rePlaceholder.FindAllString(translation, -1), rePlaceholder.FindAllString(english, -1)
+		if !slices.Equal(got, want) {
+			return fmt.Errorf( //nolint:err113 // dynamic value
+				"locale '%s': message '%s' has placeholders %v, want %v", locale, id, got, want,
+			)
+		}
+	}
+
+	// The plural rules of the messages not in catalogue are the English ones.
+	trans, err := newTranslator(en.New(), en_translations.RegisterDefaultTranslations, catalogue)
+	if err != nil {
+		return fmt.Errorf("locale '%s': %w", locale, err)
+	}
+
+	translatorsMu.Lock()
+	defer translatorsMu.Unlock()
+
+	translators[strings.ToLower(locale)] = trans
+
+	return nil
+}
+
+// placeholders are arguments for the messages that render them as their
+// templates (eg. "{0} is a required field").
+var placeholders = []string{"{0}", "{1}", "{2}", "{3}", "{4}", "{5}", "{6}", "{7}", "{8}", "{9}"}
+
+// Locales returns the registered locales, sorted.
+func Locales() []string {
+	translatorsMu.RLock()
+	defer translatorsMu.RUnlock()
+
+	return slices.Sorted(maps.Keys(translators))
+}
+
+// lookupLocale returns the translator registered for locale, or for its
+// language if there's none for the whole tag (eg. "it" for "it-IT").
+func lookupLocale(locale string) (ut.Translator, error) {
+	if locale == "" {
+		locale = DefaultLocale
+	}
+
+	translatorsMu.RLock()
+	defer translatorsMu.RUnlock()
+
+	locale = strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+
+	if trans, ok := translators[locale]; ok {
+		return trans, nil
+	}
+
+	if language, _, ok := strings.Cut(locale, "-"); ok {
+		if trans, ok := translators[language]; ok {
+			return trans, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown locale '%s'. Supported locales: %s", //nolint:err113 // dynamic value
+		locale, strings.Join(slices.Sorted(maps.Keys(translators)), ", "))
+}
+
+// translate returns the message with the ID id (eg. "unknown_field") in the
+// language of trans, or in English if it has no translation, with args in
+// place of its placeholders.
+func translate(trans ut.Translator, id string, args ...any) string {
+	params := make([]string, len(args))
+	for i, arg := range args {
+		params[i] = fmt.Sprint(arg)
+	}
+
+	message, err := trans.T(id, params...)
+	if err != nil {
+		message, _ = sharedTrans.T(id, params...)
+	}
+
+	return message
+}
+
+// message is translate in the locale of the Parser.
+func (p *Parser) message(id string, args ...any) string {
+	return translate(p.trans, id, args...)
+}
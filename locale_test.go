@@ -0,0 +1,375 @@
+package publiccode
+
+import (
+	"encoding/json"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	ut "github.com/go-playground/universal-translator"
+)
+
+func TestTranslate(t *testing.T) {
+	it, err := lookupLocale("it")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		id       string
+		args     []any
+		expected string
+	}{
+		{"required", []any{"name"}, "name è un campo obbligatorio"},
+		{"unknown_field", []any{"categorie", translate(it, "did_you_mean", "categories")}, `campo sconosciuto "categorie", forse intendevi 'categories'?`},
+		{"unknown_field", []any{"foo", ""}, `campo sconosciuto "foo"`},
+		{"invalid_video", []any{"ABC", translate(it, "invalid_oembed_link", "ABC")}, "'ABC' non è un URL di un video valido che supporta oEmbed: link oEmbed non valido: ABC"},
+	}
+
+	for _, test := range tests {
+		if got := translate(it, test.id, test.args...); got != test.expected {
+			t.Errorf("translate(%q, %v) = %q, want %q", test.id, test.args, got, test.expected)
+		}
+	}
+}
+
+// TestParserLocaleValidatorMessages checks that the messages of the
+// validator are in the locale of the Parser.
+func TestParserLocaleValidatorMessages(t *testing.T) {
+	p, err := NewParser(ParserConfig{DisableNetwork: true, Locale: "it"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		file     string
+		expected string
+	}{
+		{
+			"testdata/v0/invalid/no-network/developmentStatus_typo.yml",
+			`developmentStatus deve essere uno dei seguenti valori: "concept", "development", "beta", "stable" o "obsolete", forse intendevi 'stable'?`,
+		},
+		{
+			"testdata/v0/invalid/no-network/categories_misspelled.yml",
+			"categories[0] deve essere una categoria valida, forse intendevi 'cloud-management'? (vedi https://github.com/publiccodeyml/publiccode.yml/blob/main/docs/standard/categories-list.rst)",
+		},
+	}
+
+	for _, test := range tests {
+		_, err := p.Parse(test.file)
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("%s: unexpected error: %v", test.file, err)
+		}
+	}
+}
+
+func TestParserLocale(t *testing.T) {
+	p, err := NewParser(ParserConfig{DisableNetwork: true, Locale: "it-IT"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = p.Parse("testdata/v0/invalid/no-network/categories_typo.yml")
+
+	expected := ValidationResults{
+		ValidationError{
+			Key: "categorie", Description: "campo sconosciuto \"categorie\", forse intendevi 'categories'?", Line: 17, Column: 1, EndLine: 17, EndColumn: 9,
+			Code: "PC-UNKNOWN-FIELD",
+		},
+	}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("wrong error generated:\n%v\n- instead of:\n%v", err, expected)
+	}
+
+	if _, err := NewParser(ParserConfig{Locale: "xx"}); err == nil || !strings.Contains(err.Error(), "unknown locale") {
+		t.Errorf("unexpected error for an unknown locale: %v", err)
+	}
+}
+
+func TestRegisterLocale(t *testing.T) {
+	err := RegisterLocale("x-test", Catalogue{
+		"unknown_field": `champ inconnu "{0}"{1}`,
+		"did_you_mean":  ", vouliez-vous dire '{0}' ?",
+		"required":      "{0} est obligatoire",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := NewParser(ParserConfig{DisableNetwork: true, Locale: "x-test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = p.Parse("testdata/v0/invalid/no-network/categories_typo.yml")
+	if err == nil || !strings.Contains(err.Error(), `champ inconnu "categorie", vouliez-vous dire 'categories' ?`) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// The messages not in the Catalogue are in English.
+	if got, expected := p.message("wrong_type"), "wrong type for this field"; got != expected {
+		t.Errorf("got %q, want %q", got, expected)
+	}
+
+	if got, expected := p.message("required", "name"), "name est obligatoire"; got != expected {
+		t.Errorf("got %q, want %q", got, expected)
+	}
+
+	if err := RegisterLocale("x-test", Catalogue{"required": "{1} obligatoire"}); err == nil {
+		t.Error("expected error for an unknown placeholder")
+	}
+
+	if err := RegisterLocale("x-test", Catalogue{"no_such_message": "rien"}); err == nil {
+		t.Error("expected error for an unknown message")
+	}
+}
+
+// TestCatalogueCompleteTestcases checks that all the diagnostics produced on
+// the test cases have an Italian translation.
+func TestCatalogueCompleteTestcases(t *testing.T) {
+	en, err := NewParser(ParserConfig{DisableNetwork: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	it, err := NewParser(ParserConfig{DisableNetwork: true, Locale: "it"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	untranslated := map[string]bool{}
+
+	err = filepath.WalkDir("testdata", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".yml" {
+			return err
+		}
+
+		_, enErr := en.Parse(path)
+		_, itErr := it.Parse(path)
+
+		var enResults, itResults ValidationResults
+		if !errors.As(enErr, &enResults) || !errors.As(itErr, &itResults) || len(enResults) != len(itResults) {
+			return nil
+		}
+
+		for i := range enResults {
+			// The messages of the YAML syntax errors are the ones of the YAML
+			// library, in English.
+			if checkOf(enResults[i]) == CheckInvalidYAML {
+				continue
+			}
+
+			if enResults[i].Error() == itResults[i].Error() {
+				untranslated[enResults[i].Error()] = true
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, description := range slices.Sorted(maps.Keys(untranslated)) {
+		t.Errorf("no Italian translation for %q", description)
+	}
+}
+
+// TestCatalogueCompleteSources checks that the messages of the parser used
+// in the source files, even the ones the test cases don't produce, have an
+// Italian translation, and that catalogues/en.json has only those.
+func TestCatalogueCompleteSources(t *testing.T) {
+	it, err := lookupLocale("it")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fset := token.NewFileSet()
+	used := map[string]bool{}
+
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, lit := range messageIDs(f) {
+			id, _ := strconv.Unquote(lit.Value)
+			used[id] = true
+
+			english, err := sharedTrans.T(id, placeholders...)
+			if err != nil {
+				t.Errorf("%s: unknown message %q", fset.Position(lit.Pos()), id)
+
+				continue
+			}
+
+			if italian, err := it.T(id, placeholders...); err != nil || italian == english {
+				t.Errorf("%s: no Italian translation for %q", fset.Position(lit.Pos()), id)
+			}
+		}
+	}
+
+	data, err := builtinCatalogues.ReadFile("catalogues/en.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var catalogue Catalogue
+	if err := json.Unmarshal(data, &catalogue); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(catalogue)) {
+		if !used[id] {
+			t.Errorf("catalogues/en.json: unused message %q", id)
+		}
+	}
+}
+
+// messageIDs returns the string literals used as the IDs of the messages in
+// f: the first argument of the message methods and the second of translate.
+func messageIDs(f *ast.File) []*ast.BasicLit {
+	var lits []*ast.BasicLit
+
+	ast.Inspect(f, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		var id ast.Expr
+
+		switch fun := call.Fun.(type) {
+		case *ast.SelectorExpr:
+			if fun.Sel.Name == "message" && len(call.Args) > 0 {
+				id = call.Args[0]
+			}
+		case *ast.Ident:
+			if fun.Name == "translate" && len(call.Args) > 1 {
+				id = call.Args[1]
+			}
+		}
+
+		if lit, ok := id.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			lits = append(lits, lit)
+		}
+
+		return true
+	})
+
+	return lits
+}
+
+// TestCatalogueCompleteTags checks that the messages of all the validator
+// tags used in the publiccode structs have an Italian translation.
+func TestCatalogueCompleteTags(t *testing.T) {
+	it, err := lookupLocale("it")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tags := map[fieldTag]bool{}
+	validateTags(reflect.TypeFor[PublicCodeV0](), tags)
+	validateTags(reflect.TypeFor[PublicCodeV1](), tags)
+
+	for tag := range tags {
+		english, err := tag.message(sharedTrans)
+		if err != nil {
+			t.Errorf("%s on a %s: no message: %v", tag.name, tag.kind, err)
+
+			continue
+		}
+
+		if italian, err := tag.message(it); err != nil || italian == english {
+			t.Errorf("%s on a %s: no Italian translation for %q", tag.name, tag.kind, english)
+		}
+	}
+}
+
+// fieldTag is a validator tag on a kind of field.
+type fieldTag struct {
+	name string
+	kind reflect.Kind
+}
+
+// message returns the message of tag in the language of trans, with "X" as
+// field and 2 as parameters.
+func (tag fieldTag) message(trans ut.Translator) (string, error) {
+	// Some tags have a message for each kind of field (eg. "gt-items"),
+	// whose parameter is a count (eg. "2 items").
+	var suffix, unit string
+
+	switch tag.kind { //nolint:exhaustive // the other kinds have no specific messages
+	case reflect.String:
+		suffix, unit = "-string", "-character"
+	case reflect.Slice, reflect.Map:
+		suffix, unit = "-items", "-item"
+	case reflect.Int, reflect.Float64:
+		suffix = "-number"
+	}
+
+	if suffix != "" {
+		param, err := trans.C(tag.name+suffix+unit, 2, 0, "2")
+		if unit == "" || err != nil {
+			param = "2"
+		}
+
+		if description, err := trans.T(tag.name+suffix, "X", param); err == nil {
+			return description, nil
+		}
+	}
+
+	return trans.T(tag.name, "X", "2", "2", "2") //nolint:wrapcheck // test helper
+}
+
+// validateTags adds to tags the validator tags used in typ and in the types
+// of its fields, recursively.
+func validateTags(typ reflect.Type, tags map[fieldTag]bool) {
+	switch typ.Kind() { //nolint:exhaustive // only the types with fields matter
+	case reflect.Pointer, reflect.Slice, reflect.Map:
+		validateTags(typ.Elem(), tags)
+	case reflect.Struct:
+		for i := range typ.NumField() {
+			field := typ.Field(i)
+
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Pointer {
+				fieldType = fieldType.Elem()
+			}
+
+			for name := range strings.SplitSeq(field.Tag.Get("validate"), ",") {
+				name, _, _ = strings.Cut(name, "=")
+
+				switch name {
+				case "", "omitempty", "omitnil", "keys", "endkeys":
+				case "dive":
+					// The next tags are about the elements.
+					fieldType = fieldType.Elem()
+				default:
+					tags[fieldTag{name, fieldType.Kind()}] = true
+				}
+			}
+
+			validateTags(field.Type, tags)
+		}
+	}
+}
@@ -25,6 +25,23 @@ func (u *URL) UnmarshalYAML(unmarshal func(any) error) error {
 	return nil
 }
 
+// UnmarshalJSON implements the json.Unmarshaler interface for URLs.
+func (u *URL) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	urlp, err := url.Parse(s)
+	if err != nil {
+		return fmt.Errorf("parsing URL %q: %w", s, err)
+	}
+
+	*u = (URL)(*urlp)
+
+	return nil
+}
+
 func (u *URL) MarshalYAML() (any, error) {
 	return u.String(), nil
 }
@@ -62,3 +79,25 @@ func (a *UrlOrUrlArray) UnmarshalYAML(unmarshal func(any) error) error {
 
 	return nil
 }
+
+// UnmarshalJSON implements the json.Unmarshaler interface, accepting a single
+// URL or a list of them like UnmarshalYAML.
+func (a *UrlOrUrlArray) UnmarshalJSON(data []byte) error {
+	var multi []*URL
+
+	err := json.Unmarshal(data, &multi)
+	if err != nil {
+		var single URL
+
+		err := json.Unmarshal(data, &single)
+		if err != nil {
+			return err
+		}
+
+		*a = []*URL{&single}
+	} else {
+		*a = multi
+	}
+
+	return nil
+}
@@ -113,3 +113,41 @@ func TestUrlOrUrlArrayUnmarshalYAMLBothFail(t *testing.T) {
 		t.Error("expected error, got nil")
 	}
 }
+
+func TestURLUnmarshalJSON(t *testing.T) {
+	var u URL
+	if err := json.Unmarshal([]byte(`"https://example.com/foo"`), &u); err != nil {
+		t.Fatal(err)
+	}
+	if u.String() != "https://example.com/foo" {
+		t.Errorf("unexpected URL %s", u.String())
+	}
+
+	if err := json.Unmarshal([]byte(`["https://example.com/foo"]`), &u); err == nil {
+		t.Error("expected error for a list")
+	}
+	if err := json.Unmarshal([]byte(`"://missing-scheme"`), &u); err == nil {
+		t.Error("expected error from url.Parse for malformed URL string")
+	}
+}
+
+func TestUrlOrUrlArrayUnmarshalJSON(t *testing.T) {
+	var a UrlOrUrlArray
+	if err := json.Unmarshal([]byte(`"https://example.com"`), &a); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(a) != 1 {
+		t.Errorf("expected 1 element, got %d", len(a))
+	}
+
+	if err := json.Unmarshal([]byte(`["https://example.com/1", "https://example.com/2"]`), &a); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(a) != 2 {
+		t.Errorf("expected 2 elements, got %d", len(a))
+	}
+
+	if err := json.Unmarshal([]byte(`{}`), &a); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
@@ -1,16 +1,17 @@
 package publiccode
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"net/http"
 	"net/url"
 	"os"
 	"path"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"slices"
 	"strings"
@@ -18,34 +19,18 @@ import (
 	"unicode/utf8"
 
 	"github.com/alranel/go-vcsurl/v2"
-	"github.com/go-playground/locales/en"
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
-	en_translations "github.com/go-playground/validator/v10/translations/en"
 	yaml "github.com/goccy/go-yaml"
 	"github.com/goccy/go-yaml/ast"
 	"github.com/goccy/go-yaml/parser"
-	httpclient "github.com/italia/httpclient-lib-go"
+	"github.com/goccy/go-yaml/token"
 	urlutil "github.com/italia/publiccode-parser-go/v5/internal"
 	publiccodeValidator "github.com/italia/publiccode-parser-go/v5/validators"
 )
 
-// Build Validator and Translator once at package init.
-var (
-	sharedValidate *validator.Validate
-	sharedTrans    ut.Translator
-)
-
-func init() {
-	sharedValidate = publiccodeValidator.New()
-
-	enLocale := en.New()
-	uni := ut.New(enLocale, enLocale)
-
-	sharedTrans, _ = uni.GetTranslator("en")
-	_ = en_translations.RegisterDefaultTranslations(sharedValidate, sharedTrans)
-	_ = publiccodeValidator.RegisterLocalErrorMessages(sharedValidate, sharedTrans)
-}
+// The Validator is built once, the translators of its messages are in locale.go.
+var sharedValidate = publiccodeValidator.New()
 
 var reMapKey = regexp.MustCompile(`\[([[:alpha:]]+)\]`)
 
@@ -72,10 +57,31 @@ type ParserConfig struct {
 	// It can be a local file with the 'file' scheme.
 	BaseURL string
 
+	// BaseFS, if set, is used as base of relative files in publiccode.yml
+	// (eg. logo) in place of BaseURL, so they can be checked against an
+	// in-memory tree, an embedded FS, an archive, etc.
+	BaseFS fs.FS
+
 	// Timeout is the maximum duration for each HTTP request during external checks.
 	// Defaults to 30s if zero.
 	Timeout time.Duration
 
+	// ParseTimeout is the overall time budget for a single parse, including
+	// fetching the publiccode.yml and all the external checks. When it expires,
+	// the checks not yet completed are reported as aborted.
+	// No limit if zero.
+	ParseTimeout time.Duration
+
+	// MaxConcurrentChecks is the maximum number of external checks (eg. URL
+	// reachability, logo and screenshots) run in parallel.
+	// Defaults to 8 if zero.
+	MaxConcurrentChecks int
+
+	// ReachabilityCache, if set, is used to reuse the outcome of the network
+	// checks (eg. URLs being reachable) across Parse calls. It can be shared by
+	// multiple Parsers.
+	ReachabilityCache ReachabilityCache
+
 	// AllowNetworkToPrivateHosts allows the external checks to connect to
 	// non-public addresses (loopback, private, link-local, ...).
 	//
@@ -84,9 +90,29 @@ type ParserConfig struct {
 	// or cloud metadata endpoints. Enable it only when the input is trusted
 	// (or in tests targeting a local server).
 	AllowNetworkToPrivateHosts bool
+
+	// Rules are custom checks run after the built-in ones, in order.
+	Rules []Rule
+
+	// SeverityPolicy, if set, changes the severity of the matching
+	// diagnostics or drops them.
+	SeverityPolicy SeverityPolicy
+
+	// Locale is the language of the descriptions of the diagnostics, as a
+	// BCP 47 tag (eg. "it"). It must be one of the Locales.
+	// Defaults to DefaultLocale if empty.
+	Locale string
+
+	// EnableDraftVersions enables parsing the DraftVersions of publiccode.yml
+	// (eg. "1"), validated with their own rules and reported with a warning,
+	// as their schema is experimental and might change.
+	EnableDraftVersions bool
 }
 
-const defaultHTTPTimeout = 30 * time.Second
+const (
+	defaultHTTPTimeout         = 30 * time.Second
+	defaultMaxConcurrentChecks = 8
+)
 
 // Parser is a helper class for parsing publiccode.yml files.
 type Parser struct {
@@ -95,8 +121,15 @@ type Parser struct {
 	domain                Domain
 	branch                string
 	baseURL               *url.URL
+	baseFS                fs.FS
+	parseTimeout          time.Duration
+	maxConcurrentChecks   int
+	cache                 ReachabilityCache
+	rules                 []Rule
+	severityPolicy        []compiledOverride
+	trans                 ut.Translator
+	enableDraftVersions   bool
 	client                *http.Client
-	httpclient            *httpclient.Client
 }
 
 // Domain is a single code hosting service.
@@ -119,6 +152,11 @@ func NewParser(config ParserConfig) (*Parser, error) {
 		timeout = defaultHTTPTimeout
 	}
 
+	maxConcurrentChecks := config.MaxConcurrentChecks
+	if maxConcurrentChecks <= 0 {
+		maxConcurrentChecks = defaultMaxConcurrentChecks
+	}
+
 	// Hardened HTTP client: refuses connections to non-public addresses (SSRF)
 	// and caps the size of each response (resource exhaustion). See
 	// internal/safehttp.go.
@@ -129,8 +167,13 @@ func NewParser(config ParserConfig) (*Parser, error) {
 		disableExternalChecks: config.DisableExternalChecks,
 		domain:                config.Domain,
 		branch:                config.Branch,
+		baseFS:                config.BaseFS,
+		parseTimeout:          config.ParseTimeout,
+		maxConcurrentChecks:   maxConcurrentChecks,
+		cache:                 config.ReachabilityCache,
+		rules:                 slices.Clone(config.Rules),
+		enableDraftVersions:   config.EnableDraftVersions,
 		client:                httpClient,
-		httpclient:            httpclient.NewClient(httpClient),
 	}
 
 	if config.BaseURL != "" {
@@ -140,6 +183,15 @@ func NewParser(config ParserConfig) (*Parser, error) {
 		}
 	}
 
+	var err error
+	if p.severityPolicy, err = config.SeverityPolicy.compile(); err != nil {
+		return nil, err
+	}
+
+	if p.trans, err = lookupLocale(config.Locale); err != nil {
+		return nil, err
+	}
+
 	return &p, nil
 }
 
@@ -150,131 +202,192 @@ func NewDefaultParser() (*Parser, error) {
 
 // ParseStream reads the data and tries to parse it. Returns an error if fails.
 func (p *Parser) ParseStream(in io.Reader) (PublicCode, error) {
-	return p.parseStream(in, nil)
+	return p.ParseStreamContext(context.Background(), in)
 }
 
+// ParseStreamContext is like ParseStream, but the external checks are bound
+// to ctx: when ctx is done the checks still pending are aborted and the
+// partial ValidationResults are returned.
+func (p *Parser) ParseStreamContext(ctx context.Context, in io.Reader) (PublicCode, error) {
+	ctx, cancel := p.withParseTimeout(ctx)
+	defer cancel()
+
+	return p.parseStream(ctx, in, nil)
+}
+
+// Parse reads the publiccode.yml at uri, a local path or a remote URL, and
+// tries to parse it. Returns an error if fails.
 func (p *Parser) Parse(uri string) (PublicCode, error) {
-	var stream io.ReadCloser
+	return p.ParseContext(context.Background(), uri)
+}
+
+// ParseContext is like Parse, but fetching the file and the external checks
+// are bound to ctx: when ctx is done the checks still pending are aborted and
+// the partial ValidationResults are returned.
+func (p *Parser) ParseContext(ctx context.Context, uri string) (PublicCode, error) {
+	ctx, cancel := p.withParseTimeout(ctx)
+	defer cancel()
+
+	stream, fileURL, err := p.open(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stream.Close()
+
+	return p.parseStream(ctx, stream, fileURL)
+}
 
+// open opens the publiccode.yml at uri, a local path or a remote URL.
+func (p *Parser) open(ctx context.Context, uri string) (io.ReadCloser, *url.URL, error) {
 	fileURL, err := toURL(uri)
 	if err != nil {
-		return nil, fmt.Errorf("invalid URL '%s': %w", uri, err)
+		return nil, nil, fmt.Errorf("invalid URL '%s': %w", uri, err)
 	}
 
 	if fileURL.Scheme == "file" {
-		stream, err = os.Open(fileURL.Path)
+		stream, err := os.Open(fileURL.Path)
 		if err != nil {
-			return nil, fmt.Errorf("can't open file '%s': %w", fileURL.Path, err)
-		}
-	} else {
-		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, uri, nil)
-		if err != nil {
-			return nil, fmt.Errorf("can't build GET request for '%s': %w", uri, err)
+			return nil, nil, fmt.Errorf("can't open file '%s': %w", fileURL.Path, err)
 		}
 
-		resp, err := p.client.Do(req) //nolint:bodyclose,lll // bodyclose: closed via defer stream.Close() below; bodyclose won't realize it :(
-		if err != nil {
-			return nil, fmt.Errorf("can't GET '%s': %w", uri, err)
-		}
+		return stream, fileURL, nil
+	}
 
-		stream = resp.Body
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("can't build GET request for '%s': %w", uri, err)
 	}
 
-	defer stream.Close()
+	resp, err := p.client.Do(req) //nolint:bodyclose // closed by the caller
+	if err != nil {
+		return nil, nil, fmt.Errorf("can't GET '%s': %w", uri, err)
+	}
+
+	return resp.Body, fileURL, nil
+}
+
+// withParseTimeout returns ctx bounded by the ParseTimeout set in ParserConfig, if any.
+func (p *Parser) withParseTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if p.parseTimeout == 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, p.parseTimeout)
+}
+
+func (p *Parser) parseStream(ctx context.Context, in io.Reader, fileURL *url.URL) (PublicCode, error) {
+	b, err := p.readStream(in)
+	if err != nil {
+		return nil, err
+	}
 
-	return p.parseStream(stream, fileURL)
+	return p.parseBytes(ctx, b, fileURL)
 }
 
-func (p *Parser) parseStream(in io.Reader, fileURL *url.URL) (PublicCode, error) { //nolint:maintidx
+// readStream reads the whole YAML stream in, checking it's valid UTF-8.
+func (p *Parser) readStream(in io.Reader) ([]byte, error) {
 	b, err := io.ReadAll(in)
 	if err != nil {
-		return nil, ValidationResults{newValidationErrorf("", "Can't read the stream: %v", err)}
+		return nil, ValidationResults{withCheck(CheckInvalidYAML, newValidationError("", p.message("cant_read_stream", err)))}
 	}
 
 	if !utf8.Valid(b) {
-		return nil, ValidationResults{newValidationError("", "Invalid UTF-8")}
+		return nil, ValidationResults{withCheck(CheckInvalidYAML, newValidationError("", p.message("invalid_utf8")))}
 	}
 
+	return b, nil
+}
+
+func (p *Parser) parseBytes(ctx context.Context, b []byte, fileURL *url.URL) (PublicCode, error) { //nolint:maintidx
 	// Parse the YAML into an AST so we can look up line/column positions and
 	// detect structural issues (multi-document, empty file, syntax errors).
 	file, err := parser.ParseBytes(b, 0)
 	if err != nil {
 		var se *yaml.SyntaxError
 		if errors.As(err, &se) {
-			line := 0
-			if tok := se.GetToken(); tok != nil {
-				line = tok.Position.Line
-			}
+			ve := ValidationError{Description: se.GetMessage(), Code: CheckInvalidYAML.Code()}
+			tokenRange(se.GetToken()).setOn(&ve)
 
-			return nil, ValidationResults{ValidationError{
-				Key:         "",
-				Description: se.GetMessage(),
-				Line:        line,
-				Column:      1,
-			}}
+			return nil, ValidationResults{ve}
 		}
 
-		return nil, ValidationResults{newValidationError("", err.Error())}
+		return nil, ValidationResults{withCheck(CheckInvalidYAML, newValidationError("", err.Error()))}
 	}
 
 	if len(file.Docs) == 0 || file.Docs[0].Body == nil {
-		return nil, ValidationResults{newValidationError("publiccodeYmlVersion", "publiccodeYmlVersion is a required field")}
+		return nil, ValidationResults{withCheck(CheckInvalidValue, newValidationError(
+			"publiccodeYmlVersion", p.message("required", "publiccodeYmlVersion"),
+		))}
 	}
 
 	if len(file.Docs) > 1 {
-		return nil, ValidationResults{newValidationError("", "multiple YAML documents in one file are not supported")}
+		return nil, ValidationResults{withCheck(CheckInvalidYAML, newValidationError("", p.message("multiple_documents")))}
 	}
 
 	// Extract publiccodeYmlVersion from the AST.
 	versionPath, err := yaml.PathString("$.publiccodeYmlVersion")
 	if err != nil {
-		return nil, ValidationResults{newValidationError("", err.Error())}
+		return nil, ValidationResults{withCheck(CheckInternal, newValidationError("", err.Error()))}
 	}
 
 	versionNode, err := versionPath.FilterFile(file)
 	if err != nil || versionNode == nil {
-		return nil, ValidationResults{newValidationError("publiccodeYmlVersion", "publiccodeYmlVersion is a required field")}
+		return nil, ValidationResults{withCheck(CheckInvalidValue, newValidationError(
+			"publiccodeYmlVersion", p.message("required", "publiccodeYmlVersion"),
+		))}
 	}
 
 	strNode, ok := versionNode.(*ast.StringNode)
 	if !ok {
-		line, column := getPositionInFile("publiccodeYmlVersion", file)
-
-		return nil, ValidationResults{ValidationError{
+		ve := ValidationError{
 			Key:         "publiccodeYmlVersion",
-			Description: "wrong type for this field",
-			Line:        line,
-			Column:      column,
-		}}
+			Description: p.message("wrong_type"),
+			Code:        CheckWrongType.Code(),
+		}
+		getRangeInFile("publiccodeYmlVersion", file).setOn(&ve)
+
+		return nil, ValidationResults{ve}
 	}
 
 	version := strNode.Value
 
-	if !slices.Contains(SupportedVersions, version) {
+	supportedVersions := SupportedVersions
+	if p.enableDraftVersions {
+		supportedVersions = slices.Concat(SupportedVersions, DraftVersions)
+	}
+
+	if !slices.Contains(supportedVersions, version) {
 		return nil, ValidationResults{
-			newValidationErrorf("publiccodeYmlVersion",
-				"unsupported version: '%s'. Supported versions: %s",
-				version,
-				strings.Join(SupportedVersions, ", ")),
+			withCheck(CheckUnsupportedVersion, newValidationError("publiccodeYmlVersion",
+				p.message("unsupported_version", version, strings.Join(supportedVersions, ", ")))),
 		}
 	}
 
-	var ve ValidationResults
+	suppressions, ve := p.parseSuppressions(b, file)
+
+	if slices.Contains(DraftVersions, version) {
+		warn := ValidationWarning{
+			Key:         "publiccodeYmlVersion",
+			Description: p.message("draft_version", version),
+			Code:        CheckDraftVersion.Code(),
+		}
+		getRangeInFile("publiccodeYmlVersion", file).setOn((*ValidationError)(&warn))
+
+		ve = append(ve, warn)
+	}
 
 	if slices.Contains(SupportedVersions, version) && version != "0" && !strings.HasPrefix(version, "0.7") {
 		latestVersion := SupportedVersions[len(SupportedVersions)-1]
-		line, column := getPositionInFile("publiccodeYmlVersion", file)
-
-		ve = append(ve, ValidationWarning{
-			Key: "publiccodeYmlVersion",
-			Description: fmt.Sprintf(
-				"v%s is not the latest version, use '0'. Parsing this file as v%s.",
-				version,
-				latestVersion,
-			),
-			Line:   line,
-			Column: column,
-		})
+
+		warn := ValidationWarning{
+			Key:         "publiccodeYmlVersion",
+			Description: p.message("old_version", version, latestVersion),
+			Code:        CheckOldVersion.Code(),
+		}
+		getRangeInFile("publiccodeYmlVersion", file).setOn((*ValidationError)(&warn))
+
+		ve = append(ve, warn)
 	}
 
 	var publiccode PublicCode
@@ -287,95 +400,61 @@ func (p *Parser) parseStream(in io.Reader, fileURL *url.URL) (PublicCode, error)
 		v0 := &PublicCodeV0{}
 		validateFields = validateFieldsV0
 
-		decodeResults = decode(b, v0, file)
+		decodeResults = decode(p.trans, b, v0, file)
 		publiccode = v0
 	} else {
 		v1 := &PublicCodeV1{}
 		validateFields = validateFieldsV1
 
-		decodeResults = decode(b, v1, file)
+		decodeResults = decode(p.trans, b, v1, file)
 		publiccode = v1
 	}
 
-	if decodeResults != nil {
-		ve = append(ve, decodeResults...)
-	}
+	// The mistyped values are left out of publiccode, don't report them again
+	// (eg. as missing).
+	var mistyped []string
 
-	err = sharedValidate.Struct(publiccode)
-	if err != nil {
-		var validationErrs validator.ValidationErrors
-		if errors.As(err, &validationErrs) {
-			for _, err := range validationErrs {
-				key := strings.SplitN(err.Namespace(), ".", 2)[1]
-				key = reMapKey.ReplaceAllString(key, ".$1")
-
-				line, column := getPositionInFile(key, file)
-
-				ve = append(ve, ValidationError{
-					Key:         key,
-					Description: err.Translate(sharedTrans),
-					Line:        line,
-					Column:      column,
-				})
-			}
+	for _, result := range decodeResults {
+		var valErr ValidationError
+		if errors.As(result, &valErr) && valErr.Code == CheckWrongType.Code() {
+			mistyped = append(mistyped, valErr.Key)
 		}
 	}
 
-	// Compute the base URL for this call without mutating p (goroutine-safety).
-	var currentBaseURL *url.URL
-
-	// baseURL was not set by the user (with ParserConfig{BaseURL: "..."})),
-	// We need a base URL to perform external checks on relative files (eg. logo).
-	if p.baseURL == nil {
-		// If we parsed from an actual local or remote file, use its dir
-		if fileURL != nil {
-			u := *fileURL
-
-			currentBaseURL = &u
-			currentBaseURL.Path = path.Dir(fileURL.Path)
-		}
-	} else {
-		u := *p.baseURL
-
-		currentBaseURL = &u
+	if decodeResults != nil {
+		ve = append(ve, decodeResults...)
 	}
 
-	// Still no base URL: we parsed from a stream, try to use the publiccode.yml's `url` field
-	if currentBaseURL == nil && !p.disableNetwork && publiccode.Url() != nil {
-		rawRoot, err := vcsurl.GetRawRoot((*url.URL)(publiccode.Url()), p.branch)
-		if err != nil {
-			line, column := getPositionInFile("url", file)
-
-			ve = append(ve, ValidationError{
-				Key:         "url",
-				Description: fmt.Sprintf("failed to get raw URL for code repository at %s: %s", publiccode.Url(), err),
-				Line:        line,
-				Column:      column,
-			})
-
-			// Return early because proceeding with no base URL would result in a lot
-			// of duplicate errors stemming from its absence.
-			return asPublicCode(publiccode), ve
+	for _, valErr := range validateStruct(p.trans, publiccode) {
+		if slices.ContainsFunc(mistyped, func(k string) bool { return keyWithin(valErr.Key, k) }) {
+			continue
 		}
 
-		currentBaseURL = rawRoot
-	}
+		getRangeInFile(valErr.Key, file).setOn(&valErr)
 
-	// Still no base URL: DisableNetwork is true, use the current working directory as a fallback
-	if currentBaseURL == nil {
-		cwd, err := os.Getwd()
-		if err != nil {
-			ve = append(ve, newValidationErrorf("", "no baseURL set and failed to get working directory: %s", err))
+		ve = append(ve, valErr)
+	}
 
-			return asPublicCode(publiccode), ve
+	currentBaseURL, err := p.resolveBaseURL(ctx, publiccode, fileURL)
+	if err != nil {
+		var valErr ValidationError
+		if errors.As(err, &valErr) && valErr.Key != "" {
+			getRangeInFile(valErr.Key, file).setOn(&valErr)
+			err = valErr
 		}
 
-		currentBaseURL = &url.URL{Scheme: "file", Path: cwd}
+		ve = append(ve, err)
+
+		// Return early because proceeding with no base URL would result in a lot
+		// of duplicate errors stemming from its absence.
+		return asPublicCode(publiccode), p.applySeverity(suppress(ve, suppressions))
 	}
 
-	if err = validateFields(publiccode, p, !p.disableNetwork, currentBaseURL); err != nil {
+	if err = validateFields(ctx, publiccode, p, !p.disableNetwork, currentBaseURL); err != nil {
 		var vr ValidationResults
 		if errors.As(err, &vr) {
+			fieldResults := make(ValidationResults, 0, len(vr))
+
 			for _, result := range vr {
 				var valErr ValidationError
 
@@ -383,13 +462,19 @@ func (p *Parser) parseStream(in io.Reader, fileURL *url.URL) (PublicCode, error)
 
 				switch {
 				case errors.As(result, &valErr):
-					valErr.Line, valErr.Column = getPositionInFile(valErr.Key, file)
-					ve = append(ve, valErr)
+					getRangeInFile(valErr.Key, file).setOn(&valErr)
+					fieldResults = append(fieldResults, valErr)
 				case errors.As(result, &valWarn):
-					valWarn.Line, valWarn.Column = getPositionInFile(valWarn.Key, file)
-					ve = append(ve, valWarn)
+					getRangeInFile(valWarn.Key, file).setOn((*ValidationError)(&valWarn))
+					fieldResults = append(fieldResults, valWarn)
 				}
 			}
+
+			// External checks run concurrently and over map keys, sort the
+			// results so the output is the same on every run.
+			slices.SortStableFunc(fieldResults, compareByKeyAndLine)
+
+			ve = append(ve, fieldResults...)
 		}
 	}
 
@@ -413,6 +498,11 @@ func (p *Parser) parseStream(in io.Reader, fileURL *url.URL) (PublicCode, error)
 		}
 	}
 
+	ve = append(ve, p.runRules(ctx, asPublicCode(publiccode), file, currentBaseURL)...)
+	ve = suppress(ve, suppressions)
+	ve = append(ve, p.unusedSuppressions(suppressions)...)
+	ve = p.applySeverity(ve)
+
 	if len(ve) == 0 {
 		return asPublicCode(publiccode), nil
 	}
@@ -420,6 +510,85 @@ func (p *Parser) parseStream(in io.Reader, fileURL *url.URL) (PublicCode, error)
 	return asPublicCode(publiccode), ve
 }
 
+// validateStruct runs the validate tags of publiccode, a *PublicCodeV0 or
+// *PublicCodeV1, returning a ValidationError with no position for each
+// failed one.
+func validateStruct(trans ut.Translator, publiccode PublicCode) []ValidationError {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(sharedValidate.Struct(publiccode), &validationErrs) {
+		return nil
+	}
+
+	ve := make([]ValidationError, 0, len(validationErrs))
+
+	for _, err := range validationErrs {
+		key := strings.SplitN(err.Namespace(), ".", 2)[1]
+		key = reMapKey.ReplaceAllString(key, ".$1")
+
+		ve = append(ve, ValidationError{
+			Key:         key,
+			Description: err.Translate(trans),
+			Code:        CheckInvalidValue.Code(),
+		})
+	}
+
+	return ve
+}
+
+// resolveBaseURL returns the base URL the relative files of publiccode
+// (eg. logo) are looked up from, for the file at fileURL, if any.
+//
+// It's computed for each call without mutating p (goroutine-safety).
+func (p *Parser) resolveBaseURL(ctx context.Context, publiccode PublicCode, fileURL *url.URL) (*url.URL, error) {
+	var currentBaseURL *url.URL
+
+	// baseURL was not set by the user (with ParserConfig{BaseURL: "..."})),
+	// We need a base URL to perform external checks on relative files (eg. logo).
+	switch {
+	case p.baseFS != nil:
+		// Relative files are looked up in the fs.FS set by the user
+		currentBaseURL = &url.URL{Scheme: urlutil.FSScheme, Path: "/"}
+	case p.baseURL == nil:
+		// If we parsed from an actual local or remote file, use its dir
+		if fileURL != nil {
+			u := *fileURL
+
+			currentBaseURL = &u
+			currentBaseURL.Path = path.Dir(fileURL.Path)
+		}
+	default:
+		u := *p.baseURL
+
+		currentBaseURL = &u
+	}
+
+	// Still no base URL: we parsed from a stream, try to use the publiccode.yml's `url` field
+	if currentBaseURL == nil && !p.disableNetwork && publiccode.Url() != nil {
+		rawRoot, err := p.getRawRoot(ctx, (*url.URL)(publiccode.Url()))
+		if err != nil {
+			return nil, ValidationError{
+				Key:         "url",
+				Description: p.message("raw_url_failed", publiccode.Url(), err),
+				Code:        CheckInvalidRepository.Code(),
+			}
+		}
+
+		currentBaseURL = rawRoot
+	}
+
+	// Still no base URL: DisableNetwork is true, use the current working directory as a fallback
+	if currentBaseURL == nil {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return nil, withCheck(CheckInternal, newValidationError("", p.message("no_base_url", err)))
+		}
+
+		currentBaseURL = &url.URL{Scheme: "file", Path: cwd}
+	}
+
+	return currentBaseURL, nil
+}
+
 // Ensure the returned value implements PublicCode as a struct, not as a pointer.
 func asPublicCode(pc PublicCode) PublicCode {
 	switch v := pc.(type) {
@@ -434,18 +603,36 @@ func asPublicCode(pc PublicCode) PublicCode {
 // Uses dot notation (e.g. "organisation.name") with optional array
 // indices (e.g. "localisation.availableLanguages[0]").
 func getPositionInFile(key string, file *ast.File) (int, int) {
+	r := getRangeInFile(key, file)
+
+	return r.line, r.column
+}
+
+// sourceRange is a range in the YAML source, from its first to its last
+// character, both included. Zero if unknown.
+type sourceRange struct {
+	line, column       int
+	endLine, endColumn int
+}
+
+// setOn sets the position of e to r.
+func (r sourceRange) setOn(e *ValidationError) {
+	e.Line, e.Column, e.EndLine, e.EndColumn = r.line, r.column, r.endLine, r.endColumn
+}
+
+// getRangeInFile is like getPositionInFile, but returns the whole range of
+// the key, up to the end of its value if it's a scalar or a flow collection.
+func getRangeInFile(key string, file *ast.File) sourceRange {
 	if len(file.Docs) == 0 || file.Docs[0].Body == nil {
-		return 0, 0
+		return sourceRange{}
 	}
 
 	parts := splitKeyParts(key)
 	if len(parts) == 0 {
-		return 0, 0
+		return sourceRange{}
 	}
 
-	line, col := findKeyPos(file.Docs[0].Body, parts)
-
-	return line, col
+	return findKeyRange(file.Docs[0].Body, parts)
 }
 
 // splitKeyParts splits a dot-separated key path into parts, preserving array
@@ -457,8 +644,15 @@ func splitKeyParts(key string) []string {
 // findKeyPos traverses the AST and returns the position of the key node
 // identified by parts. Returns 0,0 if not found.
 func findKeyPos(node ast.Node, parts []string) (int, int) {
+	r := findKeyRange(node, parts)
+
+	return r.line, r.column
+}
+
+// findKeyRange is like findKeyPos, but returns the whole range.
+func findKeyRange(node ast.Node, parts []string) sourceRange {
 	if node == nil || len(parts) == 0 {
-		return 0, 0
+		return sourceRange{}
 	}
 
 	part := parts[0]
@@ -512,40 +706,36 @@ func findKeyPos(node ast.Node, parts []string) (int, int) {
 				if !ok || arrayIdx >= len(seq.Values) {
 					// Value is not a sequence or index out of bounds:
 					// fall back to the key's own position.
-					if tok := mv.Key.GetToken(); tok != nil {
-						return tok.Position.Line, tok.Position.Column
-					}
-
-					return 0, 0
+					return tokenRange(mv.Key.GetToken())
 				}
 
 				elem := seq.Values[arrayIdx]
 
 				if len(parts) == 1 {
-					tok := elem.GetToken()
-					if tok != nil {
-						return tok.Position.Line, tok.Position.Column
-					}
-
-					return 0, 0
+					return nodeRange(elem)
 				}
 
-				return findKeyPos(elem, parts[1:])
+				return findKeyRange(elem, parts[1:])
 			}
 
 			if stringKey != "" {
 				// Non-numeric bracket key (eg. "description[en-US]"): navigate
 				// into the value treating the bracket content as the next key.
-				return findKeyPos(mv.Value, append([]string{stringKey}, parts[1:]...))
+				return findKeyRange(mv.Value, append([]string{stringKey}, parts[1:]...))
 			}
 
 			if len(parts) == 1 {
-				tok := mv.Key.GetToken()
+				r := tokenRange(tok)
 
-				return tok.Position.Line, tok.Position.Column
+				// Extend the range to the value, if it's not a block collection.
+				if endLine, endColumn, ok := valueEnd(mv.Value); ok {
+					r.endLine, r.endColumn = endLine, endColumn
+				}
+
+				return r
 			}
 
-			return findKeyPos(mv.Value, parts[1:])
+			return findKeyRange(mv.Value, parts[1:])
 		}
 
 		// Key not found.
@@ -553,7 +743,7 @@ func findKeyPos(node ast.Node, parts []string) (int, int) {
 		// "legal.license" is missing).
 		if n.IsFlowStyle {
 			if tok := n.GetToken(); tok != nil {
-				return tok.Position.Line, tok.Position.Column
+				return tokenRange(tok)
 			}
 		}
 		// For block mappings, only fall back to the first child key when this is
@@ -561,21 +751,167 @@ func findKeyPos(node ast.Node, parts []string) (int, int) {
 		// looking for "legal.license") return 0,0 because we have no useful anchor.
 		if len(parts) == 1 && len(n.Values) > 0 {
 			if tok := n.Values[0].Key.GetToken(); tok != nil {
-				return tok.Position.Line, tok.Position.Column
+				return tokenRange(tok)
 			}
 		}
 	case *ast.DocumentNode:
-		return findKeyPos(n.Body, parts)
+		return findKeyRange(n.Body, parts)
 	default:
 		// Node is a scalar or unexpected type
 		// Return the node's own token so the position points to the
 		// mistyped value.
-		if tok := node.GetToken(); tok != nil {
-			return tok.Position.Line, tok.Position.Column
+		return nodeRange(node)
+	}
+
+	return sourceRange{}
+}
+
+// nodeRange returns the range of node, up to its end if it's a scalar or a
+// flow collection, or else just its first token.
+func nodeRange(node ast.Node) sourceRange {
+	if node == nil {
+		return sourceRange{}
+	}
+
+	r := tokenRange(node.GetToken())
+
+	if endLine, endColumn, ok := valueEnd(node); ok {
+		r.endLine, r.endColumn = endLine, endColumn
+	}
+
+	return r
+}
+
+// valueEnd returns the position of the last character of node, and false if
+// it's not a scalar or a flow collection.
+func valueEnd(node ast.Node) (int, int, bool) {
+	switch n := node.(type) {
+	case *ast.LiteralNode:
+		// The position of the content token of block scalars is not reliable,
+		// count the lines from the header (eg. "|") instead.
+		if n.Value == nil || n.Start == nil || n.Start.Position == nil {
+			break
+		}
+
+		content := strings.TrimRight(n.Value.GetToken().Origin, " \t\r\n")
+		if content == "" {
+			r := tokenRange(n.Start)
+
+			return r.endLine, r.endColumn, true
+		}
+
+		lines := strings.Split(content, "\n")
+
+		return n.Start.Position.Line + len(lines), utf8.RuneCountInString(lines[len(lines)-1]), true
+	case *ast.MappingNode:
+		if n.IsFlowStyle && n.End != nil && n.End.Position != nil {
+			return n.End.Position.Line, n.End.Position.Column, true
 		}
+	case *ast.SequenceNode:
+		if n.IsFlowStyle && n.End != nil && n.End.Position != nil {
+			return n.End.Position.Line, n.End.Position.Column, true
+		}
+	case ast.ScalarNode:
+		r := tokenRange(n.GetToken())
+
+		return r.endLine, r.endColumn, r.endLine > 0
 	}
 
-	return 0, 0
+	return 0, 0, false
+}
+
+// nodeEnd is like valueEnd, but it also returns the end of block collections.
+func nodeEnd(node ast.Node) (int, int, bool) {
+	switch n := node.(type) {
+	case *ast.MappingNode:
+		if !n.IsFlowStyle && len(n.Values) > 0 {
+			return nodeEnd(n.Values[len(n.Values)-1])
+		}
+	case *ast.SequenceNode:
+		if !n.IsFlowStyle && len(n.Values) > 0 {
+			return nodeEnd(n.Values[len(n.Values)-1])
+		}
+	case *ast.AnchorNode:
+		return nodeEnd(n.Value)
+	case *ast.TagNode:
+		return nodeEnd(n.Value)
+	case *ast.MappingValueNode:
+		key := tokenRange(n.Key.GetToken())
+
+		// Null values have no source text of their own, end with the key.
+		if endLine, endColumn, ok := nodeEnd(n.Value); ok && endLine >= key.line {
+			return endLine, endColumn, true
+		}
+
+		return key.endLine, key.endColumn, key.endLine > 0
+	}
+
+	return valueEnd(node)
+}
+
+// nodeAt returns the outermost node in the tree of root starting at the same
+// position as tok, or nil.
+func nodeAt(root ast.Node, tok *token.Token) ast.Node {
+	if root == nil || tok == nil || tok.Position == nil {
+		return nil
+	}
+
+	finder := &nodeFinder{line: tok.Position.Line, column: tok.Position.Column}
+	ast.Walk(finder, root)
+
+	return finder.found
+}
+
+type nodeFinder struct {
+	line, column int
+	found        ast.Node
+}
+
+func (f *nodeFinder) Visit(node ast.Node) ast.Visitor {
+	if f.found != nil {
+		return nil
+	}
+
+	if tok := node.GetToken(); tok != nil && tok.Position != nil &&
+		tok.Position.Line == f.line && tok.Position.Column == f.column {
+		f.found = node
+
+		return nil
+	}
+
+	return f
+}
+
+// tokenRange returns the range of the source text of tok.
+func tokenRange(tok *token.Token) sourceRange {
+	if tok == nil || tok.Position == nil {
+		return sourceRange{}
+	}
+
+	// Some tokens (eg. the content of block scalars) are at column 0, start
+	// from the first character.
+	r := sourceRange{line: tok.Position.Line, column: max(tok.Position.Column, 1)}
+
+	text := strings.TrimSpace(tok.Origin)
+	if text == "" {
+		text = tok.Value
+	}
+
+	lines := strings.Split(text, "\n")
+	if len(lines) == 1 {
+		r.endLine = r.line
+		r.endColumn = r.column + max(utf8.RuneCountInString(text), 1) - 1
+
+		return r
+	}
+
+	// Multiline scalars keep their indentation in Origin, except for the
+	// first line.
+	last := strings.TrimRight(lines[len(lines)-1], " \t\r")
+	r.endLine = r.line + len(lines) - 1
+	r.endColumn = max(utf8.RuneCountInString(last), 1)
+
+	return r
 }
 
 // findKeyAtLine traverses the AST and returns the dot-separated YAML
@@ -632,52 +968,239 @@ func findKeyAtLine(node ast.Node, targetLine int, prefix string) string {
 	return ""
 }
 
-// decode decodes the YAML bytes into publiccode and returns any decode errors
+// decode decodes the YAML bytes into publiccode and returns the decode errors
 // (type mismatches, unknown fields, syntax errors) as ValidationResults.
-func decode[T any](data []byte, publiccode *T, file *ast.File) ValidationResults {
+//
+// Every unknown field and mistyped value is reported: each one is removed
+// from the document and decoding retried, so the valid parts of the document
+// are decoded anyway.
+func decode[T any](trans ut.Translator, data []byte, publiccode *T, file *ast.File) ValidationResults {
 	var ve ValidationResults
 
-	d := yaml.NewDecoder(bytes.NewReader(data), yaml.DisallowUnknownField())
+	// A copy of the AST to remove the invalid nodes from, the one in file is
+	// used for the positions of the diagnostics.
+	pruned, err := parser.ParseBytes(data, 0)
+	if err != nil {
+		return append(ve, withCheck(CheckInvalidYAML, newValidationError("", err.Error())))
+	}
+
+	if len(pruned.Docs) == 0 || pruned.Docs[0].Body == nil {
+		return nil
+	}
+
+	body := pruned.Docs[0].Body
+
+	for {
+		*publiccode = *new(T)
+
+		err := yaml.NodeToValue(body, publiccode, yaml.DisallowUnknownField())
+		if err == nil {
+			return ve
+		}
 
-	if err := d.Decode(publiccode); err != nil {
 		var (
 			unknownErr *yaml.UnknownFieldError
 			yamlErr    yaml.Error
+			tok        *token.Token
 		)
 
 		switch {
 		case errors.As(err, &unknownErr):
-			line := 0
+			valErr := ValidationError{Description: unknownErr.Message, Code: CheckUnknownField.Code()}
+			tokenRange(unknownErr.Token).setOn(&valErr)
+			valErr.Key = findKeyAtLine(file.Docs[0].Body, valErr.Line, "")
+
 			if unknownErr.Token != nil {
-				line = unknownErr.Token.Position.Line
+				parts := splitKeyParts(valErr.Key)
+				candidates := knownKeys(reflect.TypeFor[T](), parts[:len(parts)-1])
+
+				var hint string
+				if suggestion, ok := publiccodeValidator.Suggest(unknownErr.Token.Value, candidates); ok {
+					hint = translate(trans, "did_you_mean", suggestion)
+				}
+
+				valErr.Description = translate(trans, "unknown_field", unknownErr.Token.Value, hint)
 			}
 
-			key := findKeyAtLine(file.Docs[0].Body, line, "")
-			ve = append(ve, ValidationError{
-				Key:         key,
-				Description: unknownErr.Message,
-				Line:        line,
-				Column:      1,
-			})
+			ve = append(ve, valErr)
+			tok = unknownErr.Token
 		case errors.As(err, &yamlErr):
-			line := 0
-			if tok := yamlErr.GetToken(); tok != nil {
-				line = tok.Position.Line
+			valErr := ValidationError{Description: translate(trans, "wrong_type"), Code: CheckWrongType.Code()}
+
+			// The range of the whole mistyped value, not just its first token.
+			r := tokenRange(yamlErr.GetToken())
+			if node := nodeAt(file.Docs[0].Body, yamlErr.GetToken()); node != nil {
+				if endLine, endColumn, ok := nodeEnd(node); ok {
+					r.endLine, r.endColumn = endLine, endColumn
+				}
 			}
 
-			key := findKeyAtLine(file.Docs[0].Body, line, "")
-			ve = append(ve, ValidationError{
-				Key:         key,
-				Description: "wrong type for this field",
-				Line:        line,
-				Column:      1,
-			})
+			r.setOn(&valErr)
+			valErr.Key = findKeyAtLine(file.Docs[0].Body, valErr.Line, "")
+
+			ve = append(ve, valErr)
+			tok = yamlErr.GetToken()
 		default:
-			ve = append(ve, newValidationError("", err.Error()))
+			return append(ve, withCheck(CheckInvalidYAML, newValidationError("", err.Error())))
+		}
+
+		if tok == nil || tok.Position == nil || !removeNodeAt(body, tok.Position) {
+			return ve
 		}
 	}
+}
 
-	return ve
+// keyWithin returns whether key is parent or one of its children.
+func keyWithin(key string, parent string) bool {
+	return key == parent || strings.HasPrefix(key, parent+".") || strings.HasPrefix(key, parent+"[")
+}
+
+// removeNodeAt removes from the tree of node the key-value pair or the
+// sequence entry starting at pos, and returns whether it found one.
+func removeNodeAt(node ast.Node, pos *token.Position) bool {
+	switch n := node.(type) {
+	case *ast.MappingNode:
+		for i, mv := range n.Values {
+			if startsAt(mv.Key, pos) || startsAt(mv.Value, pos) {
+				n.Values = slices.Delete(n.Values, i, i+1)
+
+				return true
+			}
+
+			if removeNodeAt(mv.Value, pos) {
+				return true
+			}
+		}
+	case *ast.MappingValueNode:
+		return removeNodeAt(n.Value, pos)
+	case *ast.SequenceNode:
+		for i, value := range n.Values {
+			if startsAt(value, pos) {
+				n.Values = slices.Delete(n.Values, i, i+1)
+
+				// Keep the slices parallel to Values in sync.
+				if len(n.ValueHeadComments) > i {
+					n.ValueHeadComments = slices.Delete(n.ValueHeadComments, i, i+1)
+				}
+
+				if len(n.Entries) > i {
+					n.Entries = slices.Delete(n.Entries, i, i+1)
+				}
+
+				return true
+			}
+
+			if removeNodeAt(value, pos) {
+				return true
+			}
+		}
+	case *ast.TagNode:
+		return removeNodeAt(n.Value, pos)
+	case *ast.AnchorNode:
+		return removeNodeAt(n.Value, pos)
+	}
+
+	return false
+}
+
+// startsAt returns whether the first token of node is at pos.
+func startsAt(node ast.Node, pos *token.Position) bool {
+	if node == nil {
+		return false
+	}
+
+	tok := node.GetToken()
+
+	return tok != nil && tok.Position != nil &&
+		tok.Position.Line == pos.Line && tok.Position.Column == pos.Column
+}
+
+// knownKeys returns the keys of the mapping at parts (eg. ["description",
+// "en"]) in the struct t decodes to, in the order they're declared.
+func knownKeys(t reflect.Type, parts []string) []string {
+	for _, part := range parts {
+		index := ""
+		if i := strings.Index(part, "["); i >= 0 {
+			part, index = part[:i], part[i:]
+		}
+
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		switch t.Kind() { //nolint:exhaustive // other kinds have no keys
+		case reflect.Struct:
+			field, ok := fieldByYAMLName(t, part)
+			if !ok {
+				return nil
+			}
+
+			t = field.Type
+		case reflect.Map:
+			t = t.Elem()
+		default:
+			return nil
+		}
+
+		// Step into the elements of "foo[0]" or the values of "foo[en]".
+		for ; index != ""; index = index[strings.Index(index, "]")+1:] {
+			for t.Kind() == reflect.Pointer {
+				t = t.Elem()
+			}
+
+			if t.Kind() != reflect.Slice && t.Kind() != reflect.Array && t.Kind() != reflect.Map {
+				return nil
+			}
+
+			t = t.Elem()
+		}
+	}
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	keys := make([]string, 0, t.NumField())
+	for i := range t.NumField() {
+		if name := yamlName(t.Field(i)); name != "" {
+			keys = append(keys, name)
+		}
+	}
+
+	return keys
+}
+
+func fieldByYAMLName(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := range t.NumField() {
+		if yamlName(t.Field(i)) == name {
+			return t.Field(i), true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+// yamlName returns the key of field in YAML, or an empty string if it's not
+// decoded.
+func yamlName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if name == "-" {
+		return ""
+	}
+
+	if name == "" {
+		return strings.ToLower(field.Name)
+	}
+
+	return name
 }
 
 func toURL(file string) (*url.URL, error) {
@@ -1,6 +1,8 @@
 package publiccode
 
 import (
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
 
@@ -47,7 +49,7 @@ unknownField: value
 	}
 
 	v0 := &PublicCodeV0{}
-	results := decode([]byte(yaml), v0, file)
+	results := decode(sharedTrans, []byte(yaml), v0, file)
 	if results == nil {
 		t.Error("expected error for unknown field")
 	}
@@ -66,7 +68,7 @@ name:
 	}
 
 	v0 := &PublicCodeV0{}
-	results := decode([]byte(yaml), v0, file)
+	results := decode(sharedTrans, []byte(yaml), v0, file)
 	if results == nil {
 		t.Error("expected error for wrong type")
 	}
@@ -182,3 +184,81 @@ localisation:
 		t.Error("expected at least one deprecation warning for old version")
 	}
 }
+
+func TestGetRangeInFile(t *testing.T) {
+	yaml := `name: "Medusa"
+url: [
+  "https://example.org"
+]
+description:
+  en:
+    longDescription: |
+      First line
+      Second line
+`
+	file, err := parser.ParseBytes([]byte(yaml), 0)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	tests := []struct {
+		key      string
+		expected sourceRange
+	}{
+		{"name", sourceRange{1, 1, 1, 14}},
+		{"url", sourceRange{2, 1, 4, 1}},
+		{"description.en", sourceRange{6, 3, 6, 4}},
+		{"description.en.longDescription", sourceRange{7, 5, 9, 17}},
+	}
+
+	for _, test := range tests {
+		if r := getRangeInFile(test.key, file); r != test.expected {
+			t.Errorf("%s: got %+v, want %+v", test.key, r, test.expected)
+		}
+	}
+}
+
+func TestDecodeWrongTypeRange(t *testing.T) {
+	yaml := `publiccodeYmlVersion: "0"
+name:
+  - item1
+  - item2
+`
+	file, err := parser.ParseBytes([]byte(yaml), 0)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	results := decode(sharedTrans, []byte(yaml), &PublicCodeV0{}, file)
+	if len(results) != 1 {
+		t.Fatalf("expected one error, got %v", results)
+	}
+
+	expected := ValidationError{Key: "name[0]", Description: "wrong type for this field", Line: 3, Column: 3, EndLine: 4, EndColumn: 9, Code: "PC-WRONG-TYPE"}
+	if results[0] != expected {
+		t.Errorf("got %#v, want %#v", results[0], expected)
+	}
+}
+
+func TestKnownKeys(t *testing.T) {
+	tests := []struct {
+		parts    []string
+		contains string
+	}{
+		{nil, "categories"},
+		{[]string{"description", "en"}, "shortDescription"},
+		{[]string{"dependsOn", "open[0]"}, "versionMin"},
+		{[]string{"maintenance", "contacts[1]"}, "affiliation"},
+	}
+
+	for _, test := range tests {
+		keys := knownKeys(reflect.TypeFor[PublicCodeV0](), test.parts)
+		if !slices.Contains(keys, test.contains) {
+			t.Errorf("%v: expected %q in %v", test.parts, test.contains, keys)
+		}
+	}
+
+	if keys := knownKeys(reflect.TypeFor[PublicCodeV0](), []string{"name"}); keys != nil {
+		t.Errorf("expected no keys for a scalar, got %v", keys)
+	}
+}
@@ -2,15 +2,19 @@ package publiccode
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"path"
 	"reflect"
 	"strings"
 	"sync"
 	"testing"
+	"testing/fstest"
 	"time"
 )
 
@@ -225,3 +229,158 @@ func TestToURL(t *testing.T) {
 		}
 	}
 }
+
+// slowFixture returns logo_with_url.yml with its url and logo pointing to srv.
+func slowFixture(t *testing.T, srv *httptest.Server) []byte {
+	t.Helper()
+
+	fixture, err := os.ReadFile("testdata/v0/valid/logo_with_url.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fixture = bytes.ReplaceAll(fixture,
+		[]byte("https://github.com/italia/developers.italia.it.git"),
+		[]byte(srv.URL+"/repo.git"),
+	)
+
+	return bytes.ReplaceAll(fixture,
+		[]byte("https://raw.githubusercontent.com/italia/publiccode-parser-go/refs/heads/main/testdata/v0/valid/assets/img/logo.png"),
+		[]byte(srv.URL+"/logo.png"),
+	)
+}
+
+func newSlowServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(5 * time.Second):
+		case <-r.Context().Done():
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func checkAbortedResults(t *testing.T, pc PublicCode, err error, keys ...string) {
+	t.Helper()
+
+	if pc == nil {
+		t.Fatal("expected the partially validated PublicCode, got nil")
+	}
+
+	var vr ValidationResults
+	if !errors.As(err, &vr) {
+		t.Fatalf("expected ValidationResults, got %T: %v", err, err)
+	}
+
+	for _, key := range keys {
+		found := false
+
+		for _, res := range vr {
+			var ve ValidationError
+			if errors.As(res, &ve) && ve.Key == key && strings.HasPrefix(ve.Description, "check aborted") {
+				found = true
+			}
+		}
+
+		if !found {
+			t.Errorf("expected a 'check aborted' error for %s, got:\n%v", key, vr)
+		}
+	}
+}
+
+// TestParseStreamContextCanceled checks that the external checks are
+// reported as aborted when the context is already done.
+func TestParseStreamContextCanceled(t *testing.T) {
+	srv := newSlowServer()
+	defer srv.Close()
+	defer srv.CloseClientConnections()
+
+	p, err := NewParser(ParserConfig{BaseURL: "testdata/v0/valid/", AllowNetworkToPrivateHosts: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	pc, err := p.ParseStreamContext(ctx, bytes.NewReader(slowFixture(t, srv)))
+	checkAbortedResults(t, pc, err, "url", "logo")
+}
+
+// TestParseTimeoutAbortsChecks checks that ParseTimeout bounds the whole
+// parse, and not just the single requests.
+func TestParseTimeoutAbortsChecks(t *testing.T) {
+	srv := newSlowServer()
+	defer srv.Close()
+	defer srv.CloseClientConnections()
+
+	p, err := NewParser(ParserConfig{
+		BaseURL:                    "testdata/v0/valid/",
+		ParseTimeout:               100 * time.Millisecond,
+		AllowNetworkToPrivateHosts: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+
+	pc, err := p.ParseStream(bytes.NewReader(slowFixture(t, srv)))
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("parse took %v, expected it to stop at the ParseTimeout", elapsed)
+	}
+
+	checkAbortedResults(t, pc, err, "url")
+}
+
+// mapFSFromDir returns an fstest.MapFS with the files in dir.
+func mapFSFromDir(t *testing.T, dir string, files ...string) fstest.MapFS {
+	t.Helper()
+
+	fsys := fstest.MapFS{}
+
+	for _, name := range files {
+		data, err := os.ReadFile(path.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fsys[name] = &fstest.MapFile{Data: data}
+	}
+
+	return fsys
+}
+
+// TestParseBaseFS checks that relative files are looked up in
+// ParserConfig.BaseFS when set.
+func TestParseBaseFS(t *testing.T) {
+	fsys := mapFSFromDir(t, "testdata/v0/valid/no-network",
+		"valid.yml",
+		"assets/img/logo.png",
+		"assets/img/sshot1.png",
+		"assets/img/sshot2.png",
+		"assets/img/sshot3.png",
+	)
+
+	p, err := NewParser(ParserConfig{DisableNetwork: true, BaseFS: fsys})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := p.ParseStream(bytes.NewReader(fsys["valid.yml"].Data)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	delete(fsys, "assets/img/sshot2.png")
+	fsys["assets/img/logo.png"] = &fstest.MapFile{Data: []byte("not a png")}
+
+	_, err = p.ParseStream(bytes.NewReader(fsys["valid.yml"].Data))
+
+	expected := ValidationResults{
+		ValidationError{Key: "description.en.screenshots[1]", Description: "'assets/img/sshot2.png' is not an image: no such file: assets/img/sshot2.png", Line: 169, Column: 9, EndLine: 169, EndColumn: 29, Code: "PC-INVALID-IMAGE"},
+		ValidationError{Key: "logo", Description: "image: unknown format", Line: 14, Column: 1, EndLine: 14, EndColumn: 25, Code: "PC-INVALID-LOGO"},
+	}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("unexpected error:\n%v\nexpected:\n%v", err, expected)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	publiccode "github.com/italia/publiccode-parser-go/v5"
+)
+
+// runDiff runs "publiccode-parser diff", printing the changes between two
+// publiccode.yml files, and returns the exit code.
+func runDiff(args []string) int {
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(flags.Output(), "Usage: %s diff [ OPTIONS ] old.yml new.yml\n", os.Args[0])
+		_, _ = fmt.Fprintf(flags.Output(),
+			"Print the changes from old.yml to new.yml, marking with '!' the ones needing attention.\n")
+
+		flags.PrintDefaults()
+	}
+
+	jsonOutputPtr := flags.Bool("json", false, "Output the changes in JSON.")
+
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 2 {
+		flags.Usage()
+
+		return 1
+	}
+
+	changes, err := diffFiles(flags.Arg(0), flags.Arg(1))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+
+		return 1
+	}
+
+	if *jsonOutputPtr {
+		if changes == nil {
+			changes = []publiccode.Change{}
+		}
+
+		out, err := json.MarshalIndent(changes, "", "    ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding the changes to JSON: %s\n", err.Error())
+
+			return 1
+		}
+
+		fmt.Println(string(out))
+
+		return 0
+	}
+
+	for _, change := range changes {
+		mark := " "
+		if change.Flagged {
+			mark = "!"
+		}
+
+		fmt.Printf("%s %s\n", mark, change)
+	}
+
+	return 0
+}
+
+// diffFiles returns the changes from the publiccode.yml oldFile to newFile.
+// The files are compared even with validation errors, only the keys that
+// couldn't be decoded are missing from the changes.
+func diffFiles(oldFile string, newFile string) ([]publiccode.Change, error) {
+	p, err := publiccode.NewParser(publiccode.ParserConfig{DisableExternalChecks: true})
+	if err != nil {
+		return nil, err
+	}
+
+	var pcs []publiccode.PublicCode
+
+	for _, file := range []string{oldFile, newFile} {
+		pc, err := p.Parse(file)
+		if pc == nil {
+			if err == nil {
+				err = errors.New("no publiccode data")
+			}
+
+			return nil, fmt.Errorf("can't parse %s:\n%w", file, err)
+		}
+
+		if hasValidationErrors(err) {
+			fmt.Fprintf(os.Stderr, "Warning: %s has validation errors, the changes might be incomplete\n", file)
+		}
+
+		pcs = append(pcs, pc)
+	}
+
+	return publiccode.Diff(pcs[0], pcs[1]), nil
+}
@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"os"
 	"runtime/debug"
+	"strings"
 
+	yaml "github.com/goccy/go-yaml"
 	publiccode "github.com/italia/publiccode-parser-go/v5"
 )
 
@@ -30,8 +32,24 @@ func init() {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "upgrade" {
+		os.Exit(runUpgrade(os.Args[2:]))
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "schema" {
+		os.Exit(runSchema(os.Args[2:]))
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		os.Exit(runDiff(os.Args[2:]))
+	}
+
 	flag.Usage = func() {
 		_, _ = fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [ OPTIONS ] publiccode.yml\n", os.Args[0])
+		_, _ = fmt.Fprintf(flag.CommandLine.Output(), "       %s [ OPTIONS ] -git-ref REF REPOSITORY\n", os.Args[0])
+		_, _ = fmt.Fprintf(flag.CommandLine.Output(), "       %s upgrade [ OPTIONS ] publiccode.yml\n", os.Args[0])
+		_, _ = fmt.Fprintf(flag.CommandLine.Output(), "       %s schema [ OPTIONS ]\n", os.Args[0])
+		_, _ = fmt.Fprintf(flag.CommandLine.Output(), "       %s diff [ OPTIONS ] old.yml new.yml\n", os.Args[0])
 
 		flag.PrintDefaults()
 	}
@@ -54,6 +72,43 @@ func main() {
 		"Timeout for each HTTP request during external checks (e.g. 10s, 1m). "+
 			"Defaults to 30s if not set. No effect with --no-network or --no-external-checks.",
 	)
+	parseTimeoutPtr := flag.Duration(
+		"parse-timeout", 0,
+		"Overall time budget for the validation, including all external checks (e.g. 1m). "+
+			"Checks not completed in time are reported as aborted. No limit if not set.",
+	)
+	maxConcurrentChecksPtr := flag.Int(
+		"max-concurrent-checks", 0,
+		"Maximum number of external checks run in parallel. Defaults to 8 if not set.",
+	)
+	gitRefPtr := flag.String(
+		"git-ref", "",
+		"Validate the publiccode.yml in the local git repository passed as argument, "+
+			"as it is at this `ref` (e.g. a tag or a branch). Relative files are looked up in the same commit.",
+	)
+	configPtr := flag.String(
+		"config", "",
+		"Read the severity overrides from this YAML `file`, to promote warnings to errors, "+
+			"demote errors to warnings or ignore diagnostics by key pattern or check type.",
+	)
+	fixPtr := flag.Bool(
+		"fix", false,
+		"Apply the automatic fixes (eg. uppercase country codes) to the file in place, "+
+			"keeping comments and key order, then report the remaining issues. Only for local files.",
+	)
+	snippetsPtr := flag.Bool(
+		"snippets", false,
+		"Show the offending lines of publiccode.yml under each error and warning. Only for local files.",
+	)
+	langPtr := flag.String(
+		"lang", publiccode.DefaultLocale,
+		"Language of the error and warning messages, one of: "+strings.Join(publiccode.Locales(), ", ")+".",
+	)
+	enableDraftVersionsPtr := flag.Bool(
+		"enable-draft-versions", false,
+		"Accept the draft versions of the Standard ("+strings.Join(publiccode.DraftVersions, ", ")+
+			"), whose schema is experimental.",
+	)
 	jsonOutputPtr := flag.Bool("json", false, "Output the validation errors as a JSON list.")
 	helpPtr := flag.Bool("help", false, "Display command line usage.")
 	versionPtr := flag.Bool("version", false, "Display current software version.")
@@ -78,6 +133,20 @@ func main() {
 	config.DisableNetwork = *disableNetworkPtr
 	config.DisableExternalChecks = *disableExternalChecksPtr
 	config.Timeout = *timeoutPtr
+	config.ParseTimeout = *parseTimeoutPtr
+	config.MaxConcurrentChecks = *maxConcurrentChecksPtr
+	config.Locale = *langPtr
+	config.EnableDraftVersions = *enableDraftVersionsPtr
+
+	if *configPtr != "" {
+		cfg, err := loadConfigFile(*configPtr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading config file: %s\n", err.Error())
+			os.Exit(1)
+		}
+
+		config.SeverityPolicy = cfg.Severity
+	}
 
 	p, err := publiccode.NewParser(config)
 	if err != nil {
@@ -85,7 +154,30 @@ func main() {
 		os.Exit(1)
 	}
 
-	_, err = p.Parse(publiccodeFile)
+	if *fixPtr && *gitRefPtr != "" {
+		fmt.Fprintf(os.Stderr, "-fix can't be used with -git-ref\n")
+		os.Exit(1)
+	}
+
+	if *gitRefPtr != "" {
+		_, err = p.ParseGitRepo(publiccodeFile, *gitRefPtr)
+	} else {
+		_, err = p.Parse(publiccodeFile)
+	}
+
+	if *fixPtr {
+		fixed, fixErr := fixFile(publiccodeFile, err)
+		if fixErr != nil {
+			fmt.Fprintf(os.Stderr, "Error fixing %s: %s\n", publiccodeFile, fixErr.Error())
+			os.Exit(1)
+		}
+
+		if fixed > 0 {
+			fmt.Fprintf(os.Stderr, "Fixed %d issue(s) in %s\n", fixed, publiccodeFile)
+
+			_, err = p.Parse(publiccodeFile)
+		}
+	}
 
 	if *jsonOutputPtr {
 		if err == nil {
@@ -103,7 +195,11 @@ func main() {
 
 		return
 	} else {
-		if err != nil {
+		switch {
+		case err == nil:
+		case *snippetsPtr && *gitRefPtr == "":
+			printSnippets(publiccodeFile, err)
+		default:
 			fmt.Println(err)
 		}
 
@@ -115,6 +211,89 @@ func main() {
 	}
 }
 
+// configFile is the format of the file passed with -config, eg.
+//
+//	severity:
+//	  - key: "description.*.documentation"
+//	    check: url-unreachable
+//	    severity: warning
+//	  - check: deprecated-key
+//	    severity: error
+type configFile struct {
+	Severity publiccode.SeverityPolicy `yaml:"severity"`
+}
+
+func loadConfigFile(path string) (configFile, error) {
+	var cfg configFile
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+
+	if err := yaml.UnmarshalWithOptions(b, &cfg, yaml.DisallowUnknownField()); err != nil {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// fixFile applies the fixes of the results to the local file and returns how
+// many were applied.
+func fixFile(file string, results error) (int, error) {
+	fixes := publiccode.Fixes(results)
+	if len(fixes) == 0 {
+		return 0, nil
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		return 0, err
+	}
+
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return 0, err
+	}
+
+	out, err := publiccode.ApplyFixes(src, fixes)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := os.WriteFile(file, out, info.Mode().Perm()); err != nil {
+		return 0, err
+	}
+
+	return len(fixes), nil
+}
+
+// snippeter is implemented by ValidationError and ValidationWarning.
+type snippeter interface {
+	Snippet(src []byte) string
+}
+
+// printSnippets prints the results with the offending lines of the local
+// file, or just the results if it can't be read (eg. it's a URL).
+func printSnippets(file string, results error) {
+	src, err := os.ReadFile(file)
+
+	var vr publiccode.ValidationResults
+	if err != nil || !errors.As(results, &vr) {
+		fmt.Println(results)
+
+		return
+	}
+
+	for _, res := range vr {
+		if s, ok := res.(snippeter); ok {
+			fmt.Println(s.Snippet(src))
+		} else {
+			fmt.Println(res)
+		}
+	}
+}
+
 func hasValidationErrors(results error) bool {
 	if results == nil {
 		return false
@@ -2,6 +2,10 @@ package main
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	publiccode "github.com/italia/publiccode-parser-go/v5"
@@ -46,3 +50,123 @@ func TestHasValidationErrorsMixedResults(t *testing.T) {
 		t.Error("expected true for mixed results containing an error")
 	}
 }
+
+func TestLoadConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+
+	content := `severity:
+  - key: "description.*.documentation"
+    check: url-unreachable
+    severity: warning
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := loadConfigFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := publiccode.SeverityPolicy{{
+		Key:      "description.*.documentation",
+		Check:    publiccode.CheckURLUnreachable,
+		Severity: publiccode.SeverityWarning,
+	}}
+	if !reflect.DeepEqual(cfg.Severity, expected) {
+		t.Errorf("unexpected severity policy %v, expected %v", cfg.Severity, expected)
+	}
+}
+
+func TestLoadConfigFileUnknownField(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+
+	if err := os.WriteFile(path, []byte("severty: []\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := loadConfigFile(path); err == nil {
+		t.Error("expected error for unknown field")
+	}
+}
+
+func TestUpgradeFile(t *testing.T) {
+	src, err := os.ReadFile("../testdata/v0/valid_with_warnings/valid_with_lowercase_countries.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "publiccode.yml")
+	if err := os.WriteFile(path, src, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := upgradeFile(path, true, publiccode.WriteOptions{})
+	if err != nil || len(changes) == 0 {
+		t.Fatalf("unexpected dry run result: %v, %v", changes, err)
+	}
+
+	if got, _ := os.ReadFile(path); string(got) != string(src) {
+		t.Error("the file was rewritten in dry run mode")
+	}
+
+	if _, err := upgradeFile(path, false, publiccode.WriteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the country codes change, the comments are kept.
+	got, _ := os.ReadFile(path)
+	expected := strings.Replace(string(src),
+		"    - it\n    - de\n  unsupportedCountries:\n    - us\n",
+		"    - IT\n    - DE\n  unsupportedCountries:\n    - US\n", 1)
+	if string(got) != expected {
+		t.Errorf("unexpected upgraded file:\n%s", got)
+	}
+
+	if changes, err := upgradeFile(path, false, publiccode.WriteOptions{}); err != nil || len(changes) != 0 {
+		t.Errorf("unexpected changes upgrading again: %v, %v", changes, err)
+	}
+}
+
+func TestUpgradeFileWithErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "publiccode.yml")
+	if err := os.WriteFile(path, []byte("publiccodeYmlVersion: \"0\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := upgradeFile(path, false, publiccode.WriteOptions{}); err == nil {
+		t.Error("expected error upgrading an invalid file")
+	}
+}
+
+func TestRunSchemaUnknownVersion(t *testing.T) {
+	if code := runSchema([]string{"-version", "2"}); code != 1 {
+		t.Errorf("expected exit code 1, got %d", code)
+	}
+}
+
+func TestDiffFiles(t *testing.T) {
+	src, err := os.ReadFile("../testdata/v0/valid/valid.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "publiccode.yml")
+	if err := os.WriteFile(path, []byte(strings.Replace(string(src),
+		"license: AGPL-3.0-or-later", "license: MIT", 1)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := diffFiles("../testdata/v0/valid/valid.yml", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(changes) != 1 || changes[0].Key != "legal.license" || !changes[0].Flagged {
+		t.Errorf("unexpected changes: %v", changes)
+	}
+
+	if _, err := diffFiles("../testdata/v0/valid/valid.yml", filepath.Join(t.TempDir(), "missing.yml")); err == nil {
+		t.Error("expected error diffing a missing file")
+	}
+}
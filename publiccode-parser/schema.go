@@ -0,0 +1,43 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	publiccode "github.com/italia/publiccode-parser-go/v5"
+)
+
+// runSchema runs "publiccode-parser schema", printing the JSON Schema of
+// publiccode.yml, and returns the exit code.
+func runSchema(args []string) int {
+	flags := flag.NewFlagSet("schema", flag.ExitOnError)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(flags.Output(), "Usage: %s schema [ OPTIONS ]\n", os.Args[0])
+		_, _ = fmt.Fprintf(flags.Output(),
+			"Print the JSON Schema of publiccode.yml, for editors and the YAML language server.\n")
+
+		flags.PrintDefaults()
+	}
+
+	versionPtr := flags.Uint("version", 0, "Major publiccodeYmlVersion of the schema (1 is a draft).")
+
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 0 {
+		flags.Usage()
+
+		return 1
+	}
+
+	schema, err := publiccode.JSONSchema(*versionPtr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating the JSON Schema: %s\n", err.Error())
+
+		return 1
+	}
+
+	_, _ = os.Stdout.Write(schema)
+
+	return 0
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	publiccode "github.com/italia/publiccode-parser-go/v5"
+)
+
+// runUpgrade runs "publiccode-parser upgrade", rewriting the publiccode.yml
+// to the latest version, and returns the exit code.
+func runUpgrade(args []string) int {
+	flags := flag.NewFlagSet("upgrade", flag.ExitOnError)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(flags.Output(), "Usage: %s upgrade [ OPTIONS ] publiccode.yml\n", os.Args[0])
+		_, _ = fmt.Fprintf(flags.Output(),
+			"Rewrite publiccode.yml to the latest publiccodeYmlVersion, dropping or moving the deprecated keys.\n")
+
+		flags.PrintDefaults()
+	}
+
+	dryRunPtr := flags.Bool("dry-run", false, "Only print the changes, without rewriting the file.")
+	canonicalPtr := flags.Bool("canonical", false, "Order the keys as in the Standard.")
+
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+
+		return 1
+	}
+
+	file := flags.Arg(0)
+
+	changes, err := upgradeFile(file, *dryRunPtr, publiccode.WriteOptions{Canonical: *canonicalPtr})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error upgrading %s: %s\n", file, err.Error())
+
+		return 1
+	}
+
+	for _, change := range changes {
+		fmt.Println(change)
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintf(os.Stderr, "%s is already up to date\n", file)
+	} else if !*dryRunPtr {
+		fmt.Fprintf(os.Stderr, "Upgraded %s\n", file)
+	}
+
+	return 0
+}
+
+// upgradeFile upgrades the local publiccode.yml file, unless dryRun is true,
+// and returns the changes made. Only the changed keys are rewritten.
+func upgradeFile(file string, dryRun bool, opts publiccode.WriteOptions) ([]publiccode.Change, error) {
+	p, err := publiccode.NewParser(publiccode.ParserConfig{DisableExternalChecks: true})
+	if err != nil {
+		return nil, err
+	}
+
+	// Keys with errors could be lost rewriting the file.
+	pc, err := p.Parse(file)
+	if hasValidationErrors(err) {
+		return nil, fmt.Errorf("fix the errors first:\n%w", err)
+	}
+
+	upgraded, changes := publiccode.Upgrade(pc)
+	if len(changes) == 0 || dryRun {
+		return changes, nil
+	}
+
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := publiccode.WriteYAML(src, upgraded, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(file, out, info.Mode().Perm()); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
@@ -1,5 +1,7 @@
 package publiccode
 
+import "golang.org/x/text/language"
+
 // SupportedVersions lists the publiccode.yml versions this parser supports.
 var SupportedVersions = []string{
 	"0",
@@ -10,8 +12,33 @@ var SupportedVersions = []string{
 	"0.7.0", "0.7",
 }
 
+// DraftVersions lists the draft publiccode.yml versions, whose schema is
+// experimental. They are parsed only with ParserConfig.EnableDraftVersions.
+var DraftVersions = []string{
+	"1",
+}
+
+// PublicCode is the publiccode data of any version, a PublicCodeV0 or a
+// *PublicCodeV1 when returned by the Parser.
+//
+// The Get accessors return the same data in all the versions, so that the
+// callers don't need to switch on the type. Their names don't clash with
+// the fields of the structs (eg. Name).
 type PublicCode interface {
 	Version() uint
 	Url() *URL
 	ToYAML() ([]byte, error)
+
+	GetName() string
+	GetLicense() string
+	GetLicenseExpression() (LicenseExpression, error)
+	GetDescription(lang string) (Description, bool)
+	BestDescription(acceptLanguage string, fallback language.Tag) (Description, language.Tag, bool)
+	DescriptionFor(tag language.Tag, fallback language.Tag) (Description, language.Tag, bool)
+	GetLanguages() []string
+	GetOrganisation() *Organisation
+	GetMaintenance() Maintenance
+	GetCategories() []string
+	GetLogo() string
+	GetDevelopmentStatus() string
 }
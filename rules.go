@@ -0,0 +1,71 @@
+package publiccode
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/goccy/go-yaml/ast"
+)
+
+// Rule is a custom check run by the Parser after the built-in ones, eg. to
+// enforce the requirements of a specific catalogue.
+//
+// Check gets the decoded publiccode.yml, as PublicCodeV0 or *PublicCodeV1,
+// its YAML AST and the base URL relative files are resolved against.
+// It returns the ValidationError and ValidationWarning found, if any: their
+// position, when Line is zero, is filled in by the Parser from the Key, and
+// Code, if empty, is set to the code of CheckCustomRule.
+type Rule interface {
+	Check(ctx context.Context, publiccode PublicCode, file *ast.File, baseURL *url.URL) ValidationResults
+}
+
+// RuleFunc is an adapter to use ordinary functions as Rules.
+type RuleFunc func(ctx context.Context, publiccode PublicCode, file *ast.File, baseURL *url.URL) ValidationResults
+
+// Check calls f(ctx, publiccode, file, baseURL).
+func (f RuleFunc) Check(ctx context.Context, publiccode PublicCode, file *ast.File, baseURL *url.URL) ValidationResults {
+	return f(ctx, publiccode, file, baseURL)
+}
+
+// runRules runs the custom rules and returns their results, with the
+// positions filled in.
+func (p *Parser) runRules(ctx context.Context, publiccode PublicCode, file *ast.File, baseURL *url.URL) ValidationResults {
+	var vr ValidationResults
+
+	for _, rule := range p.rules {
+		for _, result := range rule.Check(ctx, publiccode, file, baseURL) {
+			var (
+				valErr  ValidationError
+				valWarn ValidationWarning
+			)
+
+			switch {
+			case errors.As(result, &valErr):
+				if valErr.Line == 0 {
+					getRangeInFile(valErr.Key, file).setOn(&valErr)
+				}
+
+				if valErr.Code == "" {
+					valErr.Code = CheckCustomRule.Code()
+				}
+
+				vr = append(vr, valErr)
+			case errors.As(result, &valWarn):
+				if valWarn.Line == 0 {
+					getRangeInFile(valWarn.Key, file).setOn((*ValidationError)(&valWarn))
+				}
+
+				if valWarn.Code == "" {
+					valWarn.Code = CheckCustomRule.Code()
+				}
+
+				vr = append(vr, valWarn)
+			case result != nil:
+				vr = append(vr, withCheck(CheckCustomRule, newValidationError("", result.Error())))
+			}
+		}
+	}
+
+	return vr
+}
@@ -0,0 +1,57 @@
+package publiccode
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/goccy/go-yaml/ast"
+)
+
+func TestRules(t *testing.T) {
+	organisationRequired := RuleFunc(func(_ context.Context, pc PublicCode, _ *ast.File, _ *url.URL) ValidationResults {
+		if v0, ok := pc.(PublicCodeV0); ok && v0.Organisation == nil {
+			return ValidationResults{newValidationError("organisation", "organisation is required by this catalogue")}
+		}
+
+		return nil
+	})
+
+	svgLogo := RuleFunc(func(_ context.Context, pc PublicCode, _ *ast.File, baseURL *url.URL) ValidationResults {
+		if baseURL == nil {
+			return ValidationResults{errors.New("no base URL")}
+		}
+
+		if v0, ok := pc.(PublicCodeV0); ok && v0.Logo != nil && path.Ext(*v0.Logo) != ".svg" {
+			return ValidationResults{ValidationWarning{Key: "logo", Description: "logo should be an SVG"}}
+		}
+
+		return nil
+	})
+
+	fixedPosition := RuleFunc(func(_ context.Context, _ PublicCode, _ *ast.File, _ *url.URL) ValidationResults {
+		return ValidationResults{ValidationWarning{Key: "maintenance.type", Description: "fixed", Line: 1, Column: 2}}
+	})
+
+	p, err := NewParser(ParserConfig{
+		DisableNetwork: true,
+		Rules:          []Rule{organisationRequired, svgLogo, fixedPosition},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = p.Parse("testdata/v0/valid/no-network/valid.yml")
+
+	expected := ValidationResults{
+		ValidationError{Key: "organisation", Description: "organisation is required by this catalogue", Line: 4, Column: 1, EndLine: 4, EndColumn: 20, Code: "PC-CUSTOM-RULE"},
+		ValidationWarning{Key: "logo", Description: "logo should be an SVG", Line: 14, Column: 1, EndLine: 14, EndColumn: 25, Code: "PC-CUSTOM-RULE"},
+		ValidationWarning{Key: "maintenance.type", Description: "fixed", Line: 1, Column: 2, Code: "PC-CUSTOM-RULE"},
+	}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("unexpected error:\n%v\nexpected:\n%v", err, expected)
+	}
+}
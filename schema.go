@@ -0,0 +1,317 @@
+package publiccode
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	publiccodeValidator "github.com/italia/publiccode-parser-go/v5/validators"
+)
+
+// jsonSchema is the subset of JSON Schema (draft-07) used by JSONSchema.
+type jsonSchema struct {
+	Schema string `json:"$schema,omitempty"`
+	Title  string `json:"title,omitempty"`
+
+	Type    any    `json:"type,omitempty"`
+	Format  string `json:"format,omitempty"`
+	Pattern string `json:"pattern,omitempty"`
+	Enum    []any  `json:"enum,omitempty"`
+	Const   string `json:"const,omitempty"`
+
+	MinLength     *int `json:"minLength,omitempty"`
+	MaxLength     *int `json:"maxLength,omitempty"`
+	MinItems      *int `json:"minItems,omitempty"`
+	MaxItems      *int `json:"maxItems,omitempty"`
+	MinProperties *int `json:"minProperties,omitempty"`
+
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties any                    `json:"additionalProperties,omitempty"`
+
+	AnyOf []*jsonSchema `json:"anyOf,omitempty"`
+	AllOf []*jsonSchema `json:"allOf,omitempty"`
+	Not   *jsonSchema   `json:"not,omitempty"`
+	If    *jsonSchema   `json:"if,omitempty"`
+	Then  *jsonSchema   `json:"then,omitempty"`
+	Else  *jsonSchema   `json:"else,omitempty"`
+}
+
+// JSONSchema returns the JSON Schema (draft-07) of publiccode.yml for the
+// major version (0, or the draft 1), generated from the validate tags of
+// PublicCodeV0 or PublicCodeV1. It's meant for editor integrations, eg. the
+// YAML language server.
+//
+// The checks a JSON Schema can't express (eg. SPDX expressions, language
+// tags, IPA codes, existence of files and URLs) are only done by the Parser.
+func JSONSchema(version uint) ([]byte, error) {
+	var t reflect.Type
+
+	switch version {
+	case 0:
+		t = reflect.TypeFor[PublicCodeV0]()
+	case 1:
+		t = reflect.TypeFor[PublicCodeV1]()
+	default:
+		return nil, fmt.Errorf("no JSON Schema for publiccodeYmlVersion %d", version)
+	}
+
+	s := structSchema(t)
+	s.Schema = "http://json-schema.org/draft-07/schema#"
+	s.Title = fmt.Sprintf("publiccode.yml v%d", version)
+
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshaling JSON Schema: %w", err)
+	}
+
+	return append(b, '\n'), nil
+}
+
+// structSchema returns the schema of the struct type t, with a property for
+// each field.
+func structSchema(t reflect.Type) *jsonSchema {
+	s := &jsonSchema{
+		Type:                 "object",
+		Properties:           map[string]*jsonSchema{},
+		AdditionalProperties: false,
+	}
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+
+		name := strings.SplitN(field.Tag.Get("yaml"), ",", 2)[0]
+		if name == "" || name == "-" {
+			continue
+		}
+
+		tags := splitTags(field.Tag.Get("validate"))
+
+		prop, required := fieldSchema(field.Type, tags)
+		if required {
+			s.Required = append(s.Required, name)
+		} else {
+			nullable(prop)
+		}
+
+		s.Properties[name] = prop
+
+		for _, tag := range tags {
+			if cond := conditionSchema(t, name, field.Type, tag); cond != nil {
+				s.AllOf = append(s.AllOf, cond)
+			}
+		}
+	}
+
+	return s
+}
+
+// fieldSchema returns the schema of a field of type t validated with tags,
+// and whether the Parser requires the field to be there.
+func fieldSchema(t reflect.Type, tags []string) (*jsonSchema, bool) {
+	if t.Kind() == reflect.Pointer {
+		s, _ := fieldSchema(t.Elem(), tags)
+
+		// The validator reports nil pointers with any tag, unless it's
+		// optional or conditional.
+		required := len(tags) > 0 && !slices.Contains([]string{"omitempty", "omitnil", "omitzero"}, tags[0]) &&
+			!strings.HasPrefix(tags[0], "required_") && !strings.HasPrefix(tags[0], "excluded_")
+
+		return s, required
+	}
+
+	fieldTags, itemTags := tags, []string(nil)
+	if i := slices.Index(tags, "dive"); i >= 0 {
+		fieldTags, itemTags = tags[:i], tags[i+1:]
+	}
+
+	var (
+		s        *jsonSchema
+		required = slices.Contains(fieldTags, "required")
+	)
+
+	switch {
+	case t == reflect.TypeFor[UrlOrUrlArray]():
+		item, _ := fieldSchema(t.Elem(), itemTags)
+		s = &jsonSchema{AnyOf: []*jsonSchema{item, {Type: "array", Items: item}}}
+	case t == reflect.TypeFor[URL]():
+		s = &jsonSchema{Type: "string"}
+	case t.Kind() == reflect.Struct:
+		s = structSchema(t)
+
+		// A missing struct is validated as an empty one.
+		required = len(s.Required) > 0
+	case t.Kind() == reflect.Slice:
+		item, _ := fieldSchema(t.Elem(), itemTags)
+		s = &jsonSchema{Type: "array", Items: item}
+
+		if required || slices.Contains(fieldTags, "gt=0") {
+			s.MinItems, required = ptrTo(1), true
+		}
+	case t.Kind() == reflect.Map:
+		value, _ := fieldSchema(t.Elem(), itemTags)
+		s = &jsonSchema{Type: "object", AdditionalProperties: value}
+
+		if required || slices.Contains(fieldTags, "gt=0") {
+			s.MinProperties, required = ptrTo(1), true
+		}
+	case t.Kind() == reflect.Bool:
+		s = &jsonSchema{Type: "boolean"}
+	default:
+		s = &jsonSchema{Type: "string"}
+
+		if required {
+			s.MinLength = ptrTo(1)
+		}
+	}
+
+	for _, tag := range fieldTags {
+		applyTag(s, tag)
+	}
+
+	// Any scalar decodes to a string, a number or a boolean aren't mistakes
+	// unless the string has a format.
+	if s.Type == "string" && s.Enum == nil && s.AnyOf == nil && s.Pattern == "" && s.Format == "" {
+		s.Type = []string{"string", "number", "boolean"}
+	}
+
+	return s, required
+}
+
+// nullable makes s accept null too, like the Parser does for optional keys.
+func nullable(s *jsonSchema) {
+	switch typ := s.Type.(type) {
+	case string:
+		s.Type = []string{typ, "null"}
+	case []string:
+		s.Type = append(typ, "null")
+	case nil:
+		s.AnyOf = append(s.AnyOf, &jsonSchema{Type: "null"})
+	}
+
+	if s.Enum != nil {
+		s.Enum = append(s.Enum, nil)
+	}
+}
+
+// applyTag adds to s the keywords checking the validation tag, when JSON
+// Schema can express it.
+func applyTag(s *jsonSchema, tag string) {
+	name, param, _ := strings.Cut(tag, "=")
+
+	if values, ok := publiccodeValidator.Vocabulary(name); ok {
+		if name == "supports_id" {
+			// Any other URI is fine, as long as it's not an unknown alias.
+			s.AnyOf = []*jsonSchema{
+				{Enum: toAny(values)},
+				{Format: "uri", Not: &jsonSchema{Pattern: "^alias:"}},
+			}
+		} else {
+			s.Enum = toAny(values)
+		}
+
+		return
+	}
+
+	if pattern, ok := publiccodeValidator.Pattern(name); ok {
+		s.Pattern = pattern
+
+		return
+	}
+
+	switch name {
+	case "oneof":
+		s.Enum = toAny(strings.Fields(param))
+	case "umin":
+		n, _ := strconv.Atoi(param)
+		s.MinLength = ptrTo(max(n, derefOr(s.MinLength, 0)))
+	case "umax":
+		n, _ := strconv.Atoi(param)
+		s.MaxLength = ptrTo(n)
+	case "date":
+		s.Format, s.Pattern = "date", `^\d{4}-\d{2}-\d{2}$`
+	case "email":
+		s.Format = "email"
+	case "url_url", "organisation_uri":
+		s.Format = "uri"
+	case "url_http_url":
+		s.Format, s.Pattern = "uri", "^[Hh][Tt][Tt][Pp][Ss]?://"
+	}
+}
+
+// conditionSchema returns the schema checking the conditional validation tag
+// (required_if, excluded_unless) of the name property of the struct type t,
+// or nil if tag isn't one.
+func conditionSchema(t reflect.Type, name string, fieldType reflect.Type, tag string) *jsonSchema {
+	kind, param, _ := strings.Cut(tag, "=")
+
+	otherField, value, ok := strings.Cut(param, " ")
+	if !ok {
+		return nil
+	}
+
+	other, ok := t.FieldByName(otherField)
+	if !ok {
+		return nil
+	}
+
+	otherName := strings.SplitN(other.Tag.Get("yaml"), ",", 2)[0]
+	cond := &jsonSchema{
+		Properties: map[string]*jsonSchema{otherName: {Const: value}},
+		Required:   []string{otherName},
+	}
+
+	// Empty lists are as good as missing ones for the validator.
+	notEmpty := &jsonSchema{Required: []string{name}}
+	empty := &jsonSchema{}
+
+	if fieldType.Kind() == reflect.Pointer && fieldType.Elem().Kind() == reflect.Slice {
+		notEmpty.Properties = map[string]*jsonSchema{name: {MinItems: ptrTo(1)}}
+		empty.Properties = map[string]*jsonSchema{name: {MaxItems: ptrTo(0)}}
+	} else {
+		empty.Not = &jsonSchema{Required: []string{name}}
+	}
+
+	switch kind {
+	case "required_if":
+		return &jsonSchema{If: cond, Then: notEmpty}
+	case "excluded_unless":
+		return &jsonSchema{If: cond, Else: empty}
+	}
+
+	return nil
+}
+
+// splitTags splits the validate tag of a field in its single tags.
+func splitTags(validate string) []string {
+	if validate == "" {
+		return nil
+	}
+
+	return strings.Split(validate, ",")
+}
+
+func toAny(values []string) []any {
+	s := make([]any, len(values))
+	for i, v := range values {
+		s[i] = v
+	}
+
+	return s
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
+
+func derefOr[T any](p *T, def T) T {
+	if p == nil {
+		return def
+	}
+
+	return *p
+}
@@ -0,0 +1,127 @@
+package publiccode
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// Testcases with errors a JSON Schema can't express, and only the Parser
+// reports.
+var parserOnlyTestcases = []string{
+	"description_en_gb_invalid_bcp47.yml",
+	"description_en_longDescription_too_short_grapheme_clusters.yml",
+	"description_en_videos_invalid_oembed.yml",
+	"description_invalid_language.yml",
+	"file_encoding.yml",
+	"fundedBy_uri_wrong_italian_pa.yml",
+	"fundedBy_uri_wrong_italian_pa2.yml",
+	"it_IT_duplicated.yml",
+	"it_riuso_codiceIPA_invalid.yml",
+	"legal_license_invalid.yml",
+	"localisation_availableLanguages_invalid.yml",
+	"localisation_availableLanguages_invalid_bcp47.yml",
+	"logo_absolute_path.yml",
+	"logo_file_scheme.yml",
+	"logo_file_scheme2.yml",
+	"logo_file_scheme3.yml",
+	"logo_unsupported_extension.yml",
+	"monochromeLogo_unsupported_extension.yml",
+	"organisation_uri_wrong_italian_pa.yml",
+	"organisation_uri_wrong_italian_pa2.yml",
+}
+
+// TestJSONSchema checks that the JSON Schema agrees with the Parser on the
+// testcases: what the Parser accepts is valid against the schema, and what
+// it rejects isn't, unless the schema can't tell.
+func TestJSONSchema(t *testing.T) {
+	parsers := make([]*Parser, 2)
+	for version := range parsers {
+		p, err := NewParser(ParserConfig{DisableExternalChecks: true, EnableDraftVersions: version == 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		parsers[version] = p
+	}
+
+	for _, version := range []uint{0, 1} {
+		b, err := JSONSchema(version)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(b))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Compiling checks the schema against the draft-07 meta-schema too.
+		c := jsonschema.NewCompiler()
+		if err := c.AddResource("publiccode.schema.json", doc); err != nil {
+			t.Fatal(err)
+		}
+
+		schema, err := c.Compile("publiccode.schema.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		files, _ := filepath.Glob(fmt.Sprintf("testdata/v%d/*/*.yml", version))
+		more, _ := filepath.Glob(fmt.Sprintf("testdata/v%d/*/no-network/*.yml", version))
+
+		for _, file := range append(files, more...) {
+			t.Run(file, func(t *testing.T) {
+				src, err := os.ReadFile(file)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				instance, err := yamlToJSON(src)
+				if err != nil {
+					t.Skip("not YAML")
+				}
+
+				_, err = parsers[version].Parse(file)
+				parserValid := !hasErrors(err)
+				schemaErr := schema.Validate(instance)
+
+				switch {
+				case parserValid && schemaErr != nil:
+					t.Errorf("valid for the Parser, not for the schema: %v", schemaErr)
+				case !parserValid && schemaErr == nil && !slices.Contains(parserOnlyTestcases, filepath.Base(file)):
+					t.Errorf("valid for the schema, not for the Parser: %v", err)
+				}
+			})
+		}
+	}
+}
+
+func TestJSONSchemaUnknownVersion(t *testing.T) {
+	if _, err := JSONSchema(2); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+// yamlToJSON returns the YAML src as the JSON values the JSON Schema
+// validator takes.
+func yamlToJSON(src []byte) (any, error) {
+	var doc any
+	if err := yaml.Unmarshal(src, &doc); err != nil {
+		return nil, err //nolint:wrapcheck // test helper
+	}
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // test helper
+	}
+
+	return jsonschema.UnmarshalJSON(bytes.NewReader(b)) //nolint:wrapcheck // test helper
+}
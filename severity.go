@@ -0,0 +1,159 @@
+package publiccode
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Severity is the severity a diagnostic is reported with.
+type Severity string
+
+const (
+	// SeverityError reports the diagnostic as a ValidationError.
+	SeverityError Severity = "error"
+	// SeverityWarning reports the diagnostic as a ValidationWarning.
+	SeverityWarning Severity = "warning"
+	// SeverityIgnore drops the diagnostic.
+	SeverityIgnore Severity = "ignore"
+)
+
+// SeverityOverride changes the Severity of the diagnostics matching both Key
+// and Check. An empty Key or Check matches any.
+type SeverityOverride struct {
+	// Key is a pattern matched against the whole key of the diagnostic,
+	// where '*' matches a single dot separated part and '**' any number of
+	// them (eg. "description.*.documentation").
+	Key string `json:"key,omitempty" yaml:"key,omitempty"`
+
+	// Check is the type of check (eg. "url-unreachable").
+	Check Check `json:"check,omitempty" yaml:"check,omitempty"`
+
+	Severity Severity `json:"severity" yaml:"severity"`
+}
+
+// SeverityPolicy is a list of SeverityOverrides. When more than one matches a
+// diagnostic, the last one wins.
+//
+// Errors preventing the file from being parsed at all (eg. YAML syntax errors
+// or an unsupported publiccodeYmlVersion) are always reported as they are.
+type SeverityPolicy []SeverityOverride
+
+type compiledOverride struct {
+	key      *regexp.Regexp
+	check    Check
+	severity Severity
+}
+
+func (o compiledOverride) matches(key string, check Check) bool {
+	return (o.key == nil || o.key.MatchString(key)) && (o.check == "" || o.check == check)
+}
+
+// compile validates the policy and compiles the key patterns.
+func (sp SeverityPolicy) compile() ([]compiledOverride, error) {
+	compiled := make([]compiledOverride, 0, len(sp))
+
+	for i, o := range sp {
+		switch o.Severity {
+		case SeverityError, SeverityWarning, SeverityIgnore:
+		default:
+			return nil, fmt.Errorf("severity override %d: invalid severity '%s'", i, o.Severity) //nolint:err113 // dynamic value
+		}
+
+		if o.Check != "" && !o.Check.valid() {
+			return nil, fmt.Errorf("severity override %d: unknown check '%s'", i, o.Check) //nolint:err113 // dynamic value
+		}
+
+		c := compiledOverride{check: o.Check, severity: o.Severity}
+		if o.Key != "" {
+			c.key = keyPatternToRegexp(o.Key)
+		}
+
+		compiled = append(compiled, c)
+	}
+
+	return compiled, nil
+}
+
+// keyPatternToRegexp converts a key pattern (eg. "description.*.documentation")
+// to the equivalent regexp.
+func keyPatternToRegexp(pattern string) *regexp.Regexp {
+	var b strings.Builder
+
+	b.WriteString("^")
+
+	for i := 0; i < len(pattern); {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i += 2
+		case pattern[i] == '*':
+			b.WriteString(`[^.]*`)
+			i++
+		default:
+			next := strings.IndexByte(pattern[i:], '*')
+			if next < 0 {
+				next = len(pattern) - i
+			}
+
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+next]))
+			i += next
+		}
+	}
+
+	b.WriteString("$")
+
+	return regexp.MustCompile(b.String())
+}
+
+// applySeverity returns vr with the severity overrides applied, dropping the
+// ignored diagnostics.
+func (p *Parser) applySeverity(vr ValidationResults) ValidationResults {
+	out := make(ValidationResults, 0, len(vr))
+
+	for _, result := range vr {
+		var (
+			valErr  ValidationError
+			valWarn ValidationWarning
+		)
+
+		var (
+			ve       ValidationError
+			severity Severity
+		)
+
+		switch {
+		case errors.As(result, &valErr):
+			ve, severity = valErr, SeverityError
+		case errors.As(result, &valWarn):
+			ve, severity = ValidationError(valWarn), SeverityWarning
+		default:
+			out = append(out, result)
+
+			continue
+		}
+
+		check := checkOf(result)
+
+		for _, o := range p.severityPolicy {
+			if o.matches(ve.Key, check) {
+				severity = o.severity
+			}
+		}
+
+		switch severity {
+		case SeverityError:
+			out = append(out, ve)
+		case SeverityWarning:
+			out = append(out, ValidationWarning(ve))
+		case SeverityIgnore:
+		}
+	}
+
+	if len(out) == 0 {
+		return nil
+	}
+
+	return out
+}
@@ -0,0 +1,104 @@
+package publiccode
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeyPatternToRegexp(t *testing.T) {
+	tests := []struct {
+		pattern string
+		key     string
+		match   bool
+	}{
+		{"description.*.documentation", "description.en.documentation", true},
+		{"description.*.documentation", "description.en.apiDocumentation", false},
+		{"description.*.documentation", "description.en.x.documentation", false},
+		{"description.**", "description.en.screenshots[1]", true},
+		{"description.*.screenshots[*]", "description.en.screenshots[1]", true},
+		{"legal.*", "legal.authorsFile", true},
+		{"legal.*", "legal", false},
+		{"url", "url", true},
+		{"url", "landingURL", false},
+	}
+
+	for _, test := range tests {
+		if got := keyPatternToRegexp(test.pattern).MatchString(test.key); got != test.match {
+			t.Errorf("pattern %q on key %q: expected %v, got %v", test.pattern, test.key, test.match, got)
+		}
+	}
+}
+
+func TestSeverityPolicy(t *testing.T) {
+	authorsFile := ValidationResults{
+		ValidationWarning{Key: "legal.authorsFile", Description: "This key is DEPRECATED and will be removed in the future. It's safe to drop it", Line: 71, Column: 3, EndLine: 71, EndColumn: 29, Code: "PC-DEPRECATED-KEY"},
+	}
+
+	tests := []struct {
+		name     string
+		file     string
+		policy   SeverityPolicy
+		expected error
+	}{
+		{
+			"promote by check",
+			"testdata/v0/valid_with_warnings/no-network/authorsFile.yml",
+			SeverityPolicy{{Check: CheckDeprecatedKey, Severity: SeverityError}},
+			ValidationResults{ValidationError(authorsFile[0].(ValidationWarning))},
+		},
+		{
+			"ignore by key",
+			"testdata/v0/valid_with_warnings/no-network/authorsFile.yml",
+			SeverityPolicy{{Key: "legal.*", Severity: SeverityIgnore}},
+			nil,
+		},
+		{
+			"not matching check",
+			"testdata/v0/valid_with_warnings/no-network/authorsFile.yml",
+			SeverityPolicy{{Key: "legal.*", Check: CheckURLUnreachable, Severity: SeverityIgnore}},
+			authorsFile,
+		},
+		{
+			"last override wins",
+			"testdata/v0/valid_with_warnings/no-network/authorsFile.yml",
+			SeverityPolicy{
+				{Check: CheckDeprecatedKey, Severity: SeverityError},
+				{Key: "legal.authorsFile", Severity: SeverityWarning},
+			},
+			authorsFile,
+		},
+		{
+			"demote",
+			"testdata/v0/invalid/no-network/logo_invalid_png.yml",
+			SeverityPolicy{{Key: "logo", Check: CheckInvalidLogo, Severity: SeverityWarning}},
+			ValidationResults{ValidationWarning{Key: "logo", Description: "image: unknown format", Line: 18, Column: 1, EndLine: 18, EndColumn: 35, Code: "PC-INVALID-LOGO"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p, err := NewParser(ParserConfig{DisableNetwork: true, SeverityPolicy: test.policy})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = p.Parse(test.file)
+			if !reflect.DeepEqual(err, test.expected) {
+				t.Errorf("unexpected error:\n%v\nexpected:\n%v", err, test.expected)
+			}
+		})
+	}
+}
+
+func TestSeverityPolicyInvalid(t *testing.T) {
+	policies := []SeverityPolicy{
+		{{Key: "url", Severity: "fatal"}},
+		{{Check: "no-such-check", Severity: SeverityIgnore}},
+	}
+
+	for _, policy := range policies {
+		if _, err := NewParser(ParserConfig{SeverityPolicy: policy}); err == nil {
+			t.Errorf("expected error for policy %v", policy)
+		}
+	}
+}
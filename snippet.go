@@ -0,0 +1,102 @@
+package publiccode
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// snippetMaxLines is the maximum number of lines shown by a snippet, the lines
+// in the middle of longer ranges are elided.
+const snippetMaxLines = 5
+
+// Snippet renders the diagnostic along with the lines of src, the content of
+// publiccode.yml, it refers to, underlining its range, eg.
+//
+//	error[PC-WRONG-TYPE]: url: wrong type for this field
+//	 --> publiccode.yml:6:6
+//	  |
+//	6 | url: [1, 2]
+//	  |      ^^^^^^
+//
+// Only the first line is rendered if the diagnostic has no position.
+func (e ValidationError) Snippet(src []byte) string {
+	return renderSnippet("error", e, src)
+}
+
+// Snippet is like ValidationError.Snippet.
+func (e ValidationWarning) Snippet(src []byte) string {
+	return renderSnippet("warning", ValidationError(e), src)
+}
+
+func renderSnippet(severity string, e ValidationError, src []byte) string {
+	var b strings.Builder
+
+	b.WriteString(severity)
+
+	if e.Code != "" {
+		b.WriteString("[" + e.Code + "]")
+	}
+
+	b.WriteString(": ")
+
+	if e.Key != "" {
+		b.WriteString(e.Key + ": ")
+	}
+
+	b.WriteString(e.Description + "\n")
+
+	lines := strings.Split(string(src), "\n")
+	if e.Line < 1 || e.Line > len(lines) {
+		return b.String()
+	}
+
+	column := max(e.Column, 1)
+
+	// Without a known end, point to the start only.
+	endLine, endColumn := e.EndLine, e.EndColumn
+	if endLine < e.Line || (endLine == e.Line && endColumn < column) {
+		endLine, endColumn = e.Line, column
+	}
+
+	endLine = min(endLine, len(lines))
+
+	gutter := strings.Repeat(" ", len(strconv.Itoa(endLine)))
+
+	fmt.Fprintf(&b, "%s--> publiccode.yml:%d:%d\n", gutter, e.Line, column)
+	fmt.Fprintf(&b, "%s |\n", gutter)
+
+	elide := endLine-e.Line+1 > snippetMaxLines
+
+	for n := e.Line; n <= endLine; n++ {
+		if elide && n > e.Line+1 && n < endLine-1 {
+			if n == e.Line+2 {
+				b.WriteString("...\n")
+			}
+
+			continue
+		}
+
+		text := strings.TrimRight(lines[n-1], "\r")
+
+		// On the lines after the first, skip the indentation.
+		from := utf8.RuneCountInString(text) - utf8.RuneCountInString(strings.TrimLeft(text, " \t")) + 1
+		if n == e.Line {
+			from = column
+		}
+
+		to := utf8.RuneCountInString(text)
+		if n == endLine {
+			to = endColumn
+		}
+
+		fmt.Fprintf(&b, "%*d | %s\n", len(gutter), n, text)
+
+		if to >= from {
+			fmt.Fprintf(&b, "%s | %s%s\n", gutter, strings.Repeat(" ", from-1), strings.Repeat("^", to-from+1))
+		}
+	}
+
+	return b.String()
+}
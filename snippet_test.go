@@ -0,0 +1,77 @@
+package publiccode
+
+import (
+	"testing"
+)
+
+func TestSnippet(t *testing.T) {
+	src := []byte(`publiccodeYmlVersion: "0"
+url: [1, 2]
+description:
+  en:
+    features:
+      - one
+      - two
+      - three
+      - four
+      - five
+      - six
+`)
+
+	tests := []struct {
+		name     string
+		result   interface{ Snippet(src []byte) string }
+		expected string
+	}{
+		{
+			"single line",
+			ValidationError{Key: "url", Description: "wrong type for this field", Line: 2, Column: 6, EndLine: 2, EndColumn: 11, Code: "PC-WRONG-TYPE"},
+			`error[PC-WRONG-TYPE]: url: wrong type for this field
+ --> publiccode.yml:2:6
+  |
+2 | url: [1, 2]
+  |      ^^^^^^
+`,
+		},
+		{
+			"no end",
+			ValidationWarning{Key: "url", Description: "check this", Line: 2, Column: 1},
+			`warning: url: check this
+ --> publiccode.yml:2:1
+  |
+2 | url: [1, 2]
+  | ^
+`,
+		},
+		{
+			"multiple lines elided",
+			ValidationError{Key: "description.en.features", Description: "too many", Line: 6, Column: 9, EndLine: 11, EndColumn: 11, Code: "PC-INVALID-VALUE"},
+			`error[PC-INVALID-VALUE]: description.en.features: too many
+  --> publiccode.yml:6:9
+   |
+ 6 |       - one
+   |         ^^^
+ 7 |       - two
+   |       ^^^^^
+...
+10 |       - five
+   |       ^^^^^^
+11 |       - six
+   |       ^^^^^
+`,
+		},
+		{
+			"no position",
+			ValidationError{Description: "invalid file", Code: "PC-INVALID-YAML"},
+			"error[PC-INVALID-YAML]: invalid file\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.result.Snippet(src); got != test.expected {
+				t.Errorf("got:\n%s\nwant:\n%s", got, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package publiccode
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/lexer"
+	"github.com/goccy/go-yaml/token"
+)
+
+// Comments in publiccode.yml suppressing diagnostics, followed by the Checks
+// to suppress, separated by spaces or commas (eg.
+// "# publiccode-parser:ignore url-unreachable"). With no Checks, all of them
+// are suppressed.
+//
+// suppressDirective applies to the key on the same line, if it's a trailing
+// comment, or else to the key on the following line, and to all its children.
+// suppressFileDirective applies to the whole file.
+const (
+	suppressDirective     = "publiccode-parser:ignore"
+	suppressFileDirective = "publiccode-parser:ignore-file"
+)
+
+// suppression is a suppression comment found in publiccode.yml.
+type suppression struct {
+	checks []Check
+
+	// position is the range of the comment.
+	position sourceRange
+
+	// fileWide is true for suppressFileDirective, otherwise targetLine and
+	// key are the line and the key the suppression applies to.
+	fileWide   bool
+	targetLine int
+	key        string
+
+	used bool
+}
+
+// parseSuppressions returns the suppression comments in the YAML source,
+// and warnings for the malformed ones.
+func (p *Parser) parseSuppressions(src []byte, file *ast.File) ([]*suppression, ValidationResults) {
+	var (
+		suppressions []*suppression
+		vr           ValidationResults
+	)
+
+	tokens := lexer.Tokenize(string(src))
+
+	for i, tk := range tokens {
+		if tk.Type != token.CommentType {
+			continue
+		}
+
+		directive, args, _ := strings.Cut(strings.TrimSpace(tk.Value), " ")
+		if directive != suppressDirective && directive != suppressFileDirective {
+			continue
+		}
+
+		s := &suppression{
+			position: tokenRange(tk),
+			fileWide: directive == suppressFileDirective,
+		}
+
+		names := strings.FieldsFunc(args, isSuppressionSeparator)
+
+		for _, name := range names {
+			if !Check(name).valid() {
+				warn := ValidationWarning{
+					Description: p.message("unknown_suppressed_check", name),
+					Code:        CheckInvalidSuppression.Code(),
+				}
+				s.position.setOn((*ValidationError)(&warn))
+
+				vr = append(vr, warn)
+
+				continue
+			}
+
+			s.checks = append(s.checks, Check(name))
+		}
+
+		// Don't turn a comment with just unknown checks into one suppressing everything.
+		if len(names) > 0 && len(s.checks) == 0 {
+			continue
+		}
+
+		if !s.fileWide {
+			s.targetLine = suppressionTargetLine(tokens, i)
+			if len(file.Docs) > 0 {
+				s.key = findKeyAtLine(file.Docs[0].Body, s.targetLine, "")
+			}
+		}
+
+		suppressions = append(suppressions, s)
+	}
+
+	return suppressions, vr
+}
+
+func isSuppressionSeparator(r rune) bool {
+	return r == ' ' || r == ',' || r == '\t'
+}
+
+// suppressionTargetLine returns the line the comment at tokens[i] applies to:
+// its own line if it's a trailing comment, the line of the next token if not.
+func suppressionTargetLine(tokens token.Tokens, i int) int {
+	line := tokens[i].Position.Line
+
+	if i > 0 && tokens[i-1].Position.Line == line {
+		return line
+	}
+
+	for _, tk := range tokens[i+1:] {
+		if tk.Type != token.CommentType {
+			return tk.Position.Line
+		}
+	}
+
+	return line
+}
+
+// matches returns whether s suppresses a diagnostic on key at line from check.
+func (s *suppression) matches(key string, line int, check Check) bool {
+	if len(s.checks) > 0 && !slices.Contains(s.checks, check) {
+		return false
+	}
+
+	if s.fileWide || line == s.targetLine {
+		return true
+	}
+
+	return s.key != "" && keyWithin(key, s.key)
+}
+
+// suppress returns vr without the diagnostics suppressed by suppressions.
+func suppress(vr ValidationResults, suppressions []*suppression) ValidationResults {
+	if len(suppressions) == 0 {
+		return vr
+	}
+
+	return slices.DeleteFunc(vr, func(result error) bool {
+		var (
+			valErr  ValidationError
+			valWarn ValidationWarning
+			key     string
+			line    int
+		)
+
+		switch {
+		case errors.As(result, &valErr):
+			key, line = valErr.Key, valErr.Line
+		case errors.As(result, &valWarn):
+			key, line = valWarn.Key, valWarn.Line
+		default:
+			return false
+		}
+
+		suppressed := false
+
+		for _, s := range suppressions {
+			if s.matches(key, line, checkOf(result)) {
+				s.used = true
+				suppressed = true
+			}
+		}
+
+		return suppressed
+	})
+}
+
+// unusedSuppressions returns a warning for each suppression that didn't
+// suppress anything, unless its checks might not have run at all.
+func (p *Parser) unusedSuppressions(suppressions []*suppression) ValidationResults {
+	var vr ValidationResults
+
+	for _, s := range suppressions {
+		if s.used || p.mightNotRun(s.checks) {
+			continue
+		}
+
+		warn := ValidationWarning{
+			Key:         s.key,
+			Description: p.message("unused_suppression"),
+			Code:        CheckUnusedSuppression.Code(),
+		}
+		s.position.setOn((*ValidationError)(&warn))
+
+		vr = append(vr, warn)
+	}
+
+	return vr
+}
+
+// mightNotRun returns whether any of suppressed (or any check, if empty) is
+// disabled by the configuration or depends on timing.
+func (p *Parser) mightNotRun(suppressed []Check) bool {
+	if len(suppressed) == 0 {
+		return p.disableNetwork
+	}
+
+	for _, check := range suppressed {
+		switch check { //nolint:exhaustive // only the checks that might not run
+		case CheckURLUnreachable, CheckInvalidRepository:
+			if p.disableNetwork {
+				return true
+			}
+		case CheckInvalidLogo, CheckInvalidImage, CheckFileNotFound:
+			if p.disableExternalChecks {
+				return true
+			}
+		case CheckAborted:
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,87 @@
+package publiccode
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSuppressionComments(t *testing.T) {
+	dir := "testdata/v0/valid_with_warnings/no-network"
+
+	b, err := os.ReadFile(dir + "/authorsFile.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := string(b)
+	authorsFileComment := "# file listing copyright information"
+
+	authorsFile := ValidationWarning{
+		Key: "legal.authorsFile", Description: "This key is DEPRECATED and will be removed in the future. It's safe to drop it", Line: 71, Column: 3,
+		EndLine: 71, EndColumn: 29, Code: "PC-DEPRECATED-KEY",
+	}
+
+	tests := []struct {
+		name     string
+		src      string
+		expected error
+	}{
+		{
+			"trailing",
+			strings.Replace(src, authorsFileComment, "# publiccode-parser:ignore deprecated-key", 1),
+			nil,
+		},
+		{
+			"above a parent key",
+			strings.Replace(src, "\nlegal:", "\n# publiccode-parser:ignore deprecated-key\nlegal:", 1),
+			nil,
+		},
+		{
+			"all checks",
+			strings.Replace(src, authorsFileComment, "# publiccode-parser:ignore", 1),
+			nil,
+		},
+		{
+			"file",
+			"# publiccode-parser:ignore-file invalid-value, deprecated-key\n" + src,
+			nil,
+		},
+		{
+			"other check",
+			strings.Replace(src, authorsFileComment, "# publiccode-parser:ignore invalid-video", 1),
+			ValidationResults{
+				authorsFile,
+				ValidationWarning{Key: "legal.authorsFile", Description: "unused suppression comment, nothing to suppress here", Line: 71, Column: 31, EndLine: 71, EndColumn: 70, Code: "PC-UNUSED-SUPPRESSION"},
+			},
+		},
+		{
+			"check not run",
+			strings.Replace(src, authorsFileComment, "# publiccode-parser:ignore url-unreachable", 1),
+			ValidationResults{authorsFile},
+		},
+		{
+			"unknown check",
+			strings.Replace(src, authorsFileComment, "# publiccode-parser:ignore no-such-check", 1),
+			ValidationResults{
+				ValidationWarning{Description: "unknown check 'no-such-check' in suppression comment", Line: 71, Column: 31, EndLine: 71, EndColumn: 70, Code: "PC-INVALID-SUPPRESSION"},
+				authorsFile,
+			},
+		},
+	}
+
+	p, err := NewParser(ParserConfig{DisableNetwork: true, BaseURL: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := p.ParseStream(strings.NewReader(test.src))
+			if !reflect.DeepEqual(err, test.expected) {
+				t.Errorf("unexpected error:\n%v\nexpected:\n%v", err, test.expected)
+			}
+		})
+	}
+}
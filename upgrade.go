@@ -0,0 +1,329 @@
+package publiccode
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// latestVersion is the publiccodeYmlVersion files are upgraded to, always
+// meaning the latest minor version.
+const latestVersion = "0"
+
+// ChangeKind is the kind of a Change.
+type ChangeKind string
+
+const (
+	// ChangeAdded is a key added.
+	ChangeAdded ChangeKind = "added"
+	// ChangeRemoved is a key removed.
+	ChangeRemoved ChangeKind = "removed"
+	// ChangeModified is a key whose value changed.
+	ChangeModified ChangeKind = "modified"
+	// ChangeMoved is a value moved from Key to To.
+	ChangeMoved ChangeKind = "moved"
+)
+
+// Change is a change to a publiccode.yml.
+type Change struct {
+	Kind ChangeKind `json:"kind"`
+
+	// Key is the changed key (eg. "legal.authorsFile").
+	Key string `json:"key"`
+
+	// To is the key the value was moved to, for ChangeMoved.
+	To string `json:"to,omitempty"`
+
+	// Old and New are the values before and after the change, if any.
+	Old any `json:"old,omitempty"`
+	New any `json:"new,omitempty"`
+
+	Description string `json:"description"`
+
+	// Flagged is set by Diff on the changes that need attention, like a
+	// license change or the software becoming obsolete.
+	Flagged bool `json:"flagged,omitempty"`
+}
+
+func (c Change) String() string {
+	return c.Key + ": " + c.Description
+}
+
+// piattaformeAliases are the supports aliases replacing IT.piattaforme.
+var piattaformeAliases = []struct {
+	key   string
+	alias string
+	value func(it *ITSectionV0) bool
+}{
+	{"spid", "alias:spid", func(it *ITSectionV0) bool { return it.Piattaforme.SPID }},
+	{"pagopa", "alias:pagopa", func(it *ITSectionV0) bool { return it.Piattaforme.PagoPa }},
+	{"cie", "alias:cie", func(it *ITSectionV0) bool { return it.Piattaforme.CIE }},
+	{"anpr", "alias:anpr", func(it *ITSectionV0) bool { return it.Piattaforme.ANPR }},
+	{"io", "alias:io", func(it *ITSectionV0) bool { return it.Piattaforme.Io }},
+}
+
+// Upgrade returns publiccode upgraded to the latest publiccodeYmlVersion,
+// along with the Changes made: the deprecated keys are dropped or moved to
+// the ones replacing them, and the country codes uppercased.
+//
+// publiccode is not modified. Versions other than v0 are returned as they are.
+func Upgrade(publiccode PublicCode) (PublicCode, []Change) {
+	var v0 PublicCodeV0
+
+	switch pc := publiccode.(type) {
+	case PublicCodeV0:
+		v0 = pc
+	case *PublicCodeV0:
+		if pc == nil {
+			return publiccode, nil
+		}
+
+		v0 = *pc
+	default:
+		return publiccode, nil
+	}
+
+	var changes []Change
+
+	if v0.PubliccodeYamlVersion != latestVersion {
+		changes = append(changes, Change{
+			Kind:        ChangeModified,
+			Key:         "publiccodeYmlVersion",
+			Old:         v0.PubliccodeYamlVersion,
+			New:         latestVersion,
+			Description: fmt.Sprintf("upgraded from '%s' to '%s'", v0.PubliccodeYamlVersion, latestVersion),
+		})
+		v0.PubliccodeYamlVersion = latestVersion
+	}
+
+	if v0.MonochromeLogo != nil {
+		changes = append(changes, removed("monochromeLogo", *v0.MonochromeLogo))
+		v0.MonochromeLogo = nil
+	}
+
+	if v0.InputTypes != nil {
+		changes = append(changes, removed("inputTypes", *v0.InputTypes))
+		v0.InputTypes = nil
+	}
+
+	if v0.OutputTypes != nil {
+		changes = append(changes, removed("outputTypes", *v0.OutputTypes))
+		v0.OutputTypes = nil
+	}
+
+	if v0.Legal.AuthorsFile != nil {
+		changes = append(changes, removed("legal.authorsFile", *v0.Legal.AuthorsFile))
+		v0.Legal.AuthorsFile = nil
+	}
+
+	changes = append(changes, upgradeDescriptions(&v0)...)
+	changes = append(changes, upgradeCountries(&v0)...)
+	changes = append(changes, upgradeITSection(&v0)...)
+	changes = append(changes, upgradeRepoOwner(&v0)...)
+
+	return v0, changes
+}
+
+func removed(key string, old any) Change {
+	return Change{Kind: ChangeRemoved, Key: key, Old: old, Description: "removed, it's deprecated"}
+}
+
+// upgradeDescriptions drops genericName from the descriptions of v0.
+func upgradeDescriptions(v0 *PublicCodeV0) []Change {
+	var changes []Change
+
+	descriptions := maps.Clone(v0.Description)
+
+	for _, lang := range slices.Sorted(maps.Keys(descriptions)) {
+		desc := descriptions[lang]
+		if desc.GenericName == "" {
+			continue
+		}
+
+		changes = append(changes, removed(fmt.Sprintf("description.%s.genericName", lang), desc.GenericName))
+
+		desc.GenericName = ""
+		descriptions[lang] = desc
+	}
+
+	if changes != nil {
+		v0.Description = descriptions
+	}
+
+	return changes
+}
+
+// upgradeCountries uppercases the country codes in v0.
+func upgradeCountries(v0 *PublicCodeV0) []Change {
+	if v0.IntendedAudience == nil {
+		return nil
+	}
+
+	var changes []Change
+
+	intendedAudience := *v0.IntendedAudience
+
+	uppercase := func(key string, countries *[]string) *[]string {
+		if countries == nil {
+			return nil
+		}
+
+		upper := slices.Clone(*countries)
+
+		for i, c := range upper {
+			if c != strings.ToUpper(c) {
+				upper[i] = strings.ToUpper(c)
+				changes = append(changes, Change{
+					Kind:        ChangeModified,
+					Key:         fmt.Sprintf("%s[%d]", key, i),
+					Old:         c,
+					New:         upper[i],
+					Description: fmt.Sprintf("uppercased '%s' to '%s'", c, upper[i]),
+				})
+			}
+		}
+
+		return &upper
+	}
+
+	intendedAudience.Countries = uppercase("intendedAudience.countries", intendedAudience.Countries)
+	intendedAudience.UnsupportedCountries = uppercase(
+		"intendedAudience.unsupportedCountries", intendedAudience.UnsupportedCountries,
+	)
+
+	if changes != nil {
+		v0.IntendedAudience = &intendedAudience
+	}
+
+	return changes
+}
+
+// upgradeITSection moves the deprecated lowercase it section to IT, and
+// IT.riuso.codiceIPA and IT.piattaforme to organisation.uri and supports.
+func upgradeITSection(v0 *PublicCodeV0) []Change {
+	var changes []Change
+
+	// The Parser sets IT to it, when only the latter is in the file.
+	if v0.It != nil {
+		if v0.IT == nil || v0.IT == v0.It {
+			changes = append(changes, Change{
+				Kind: ChangeMoved, Key: "it", To: "IT", Description: "moved to 'IT', lowercase country codes are deprecated",
+			})
+			v0.IT = v0.It
+		} else {
+			changes = append(changes, Change{
+				Kind: ChangeRemoved, Key: "it", Description: "removed, 'IT' is already present",
+			})
+		}
+
+		v0.It = nil
+	}
+
+	if v0.IT == nil {
+		return changes
+	}
+
+	it := *v0.IT
+	v0.IT = &it
+
+	if it.Conforme != nil {
+		changes = append(changes, removed("IT.conforme", *it.Conforme))
+		it.Conforme = nil
+	}
+
+	if codiceIPA := it.Riuso.CodiceIPA; codiceIPA != "" {
+		uri := "urn:x-italian-pa:" + codiceIPA
+
+		organisation := OrganisationV0{URI: uri}
+		if v0.Organisation != nil && v0.Organisation.URI != "" {
+			organisation = *v0.Organisation
+		}
+
+		if organisation.URI == uri {
+			changes = append(changes, Change{
+				Kind: ChangeMoved, Key: "IT.riuso.codiceIPA", To: "organisation.uri", Old: codiceIPA, New: uri,
+				Description: fmt.Sprintf("moved to 'organisation.uri' as '%s'", uri),
+			})
+		} else {
+			changes = append(changes, Change{
+				Kind: ChangeRemoved, Key: "IT.riuso.codiceIPA", Old: codiceIPA,
+				Description: "removed, 'organisation.uri' is already set",
+			})
+		}
+
+		v0.Organisation = &organisation
+		it.Riuso.CodiceIPA = ""
+	}
+
+	var supports []SupportV0
+	if v0.Supports != nil {
+		supports = slices.Clone(*v0.Supports)
+	}
+
+	movedPiattaforme := false
+
+	for _, p := range piattaformeAliases {
+		if !p.value(&it) {
+			continue
+		}
+
+		movedPiattaforme = true
+
+		key := "IT.piattaforme." + p.key
+		if slices.Contains(supports, SupportV0{ID: p.alias}) {
+			changes = append(changes, Change{
+				Kind: ChangeRemoved, Key: key, Old: true,
+				Description: fmt.Sprintf("removed, '%s' is already in 'supports'", p.alias),
+			})
+
+			continue
+		}
+
+		supports = append(supports, SupportV0{ID: p.alias})
+		changes = append(changes, Change{
+			Kind: ChangeMoved, Key: key, To: fmt.Sprintf("supports[%d].id", len(supports)-1), Old: true, New: p.alias,
+			Description: fmt.Sprintf("moved to 'supports' as '%s'", p.alias),
+		})
+	}
+
+	if movedPiattaforme {
+		v0.Supports = &supports
+		it.Piattaforme = ITSectionV0{}.Piattaforme
+	}
+
+	// Drop the section if nothing's left in it.
+	if it.CountryExtensionVersion == nil && it.Conforme == nil && it.Riuso.CodiceIPA == "" &&
+		it.Piattaforme == (ITSectionV0{}).Piattaforme {
+		v0.IT = nil
+	}
+
+	return changes
+}
+
+// upgradeRepoOwner moves legal.repoOwner to organisation.name, if there's an
+// organisation to move it to.
+func upgradeRepoOwner(v0 *PublicCodeV0) []Change {
+	repoOwner := v0.Legal.RepoOwner
+	if repoOwner == nil || v0.Organisation == nil {
+		return nil
+	}
+
+	organisation := *v0.Organisation
+	v0.Organisation = &organisation
+	v0.Legal.RepoOwner = nil
+
+	if organisation.Name != nil && *organisation.Name != *repoOwner {
+		return []Change{{
+			Kind: ChangeRemoved, Key: "legal.repoOwner", Old: *repoOwner,
+			Description: "removed, 'organisation.name' is already set",
+		}}
+	}
+
+	organisation.Name = repoOwner
+
+	return []Change{{
+		Kind: ChangeMoved, Key: "legal.repoOwner", To: "organisation.name", Old: *repoOwner, New: *repoOwner,
+		Description: "moved to 'organisation.name'",
+	}}
+}
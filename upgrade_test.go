@@ -0,0 +1,163 @@
+package publiccode
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestUpgrade(t *testing.T) {
+	p, err := NewParser(ParserConfig{DisableExternalChecks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pc, err := p.Parse("testdata/v0/valid_with_warnings/valid_with_IT_riuso_codiceIPA.yml")
+	if err == nil {
+		t.Fatal("expected deprecation warnings")
+	}
+
+	upgraded, changes := Upgrade(pc)
+
+	expected := []Change{
+		{
+			Kind: ChangeMoved, Key: "IT.riuso.codiceIPA", To: "organisation.uri", Old: "pcm", New: "urn:x-italian-pa:pcm",
+			Description: "moved to 'organisation.uri' as 'urn:x-italian-pa:pcm'",
+		},
+		{
+			Kind: ChangeMoved, Key: "IT.piattaforme.spid", To: "supports[0].id", Old: true, New: "alias:spid",
+			Description: "moved to 'supports' as 'alias:spid'",
+		},
+		{
+			Kind: ChangeMoved, Key: "IT.piattaforme.pagopa", To: "supports[1].id", Old: true, New: "alias:pagopa",
+			Description: "moved to 'supports' as 'alias:pagopa'",
+		},
+		{
+			Kind: ChangeMoved, Key: "IT.piattaforme.cie", To: "supports[2].id", Old: true, New: "alias:cie",
+			Description: "moved to 'supports' as 'alias:cie'",
+		},
+		{
+			Kind: ChangeMoved, Key: "IT.piattaforme.anpr", To: "supports[3].id", Old: true, New: "alias:anpr",
+			Description: "moved to 'supports' as 'alias:anpr'",
+		},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("wrong changes:\n%v\n- instead of:\n%v", changes, expected)
+	}
+
+	v0, ok := upgraded.(PublicCodeV0)
+	if !ok {
+		t.Fatalf("unexpected type %T", upgraded)
+	}
+
+	if v0.IT != nil {
+		t.Errorf("expected the empty IT section to be dropped, got %+v", v0.IT)
+	}
+
+	// The original is left untouched.
+	if original := pc.(PublicCodeV0); original.IT == nil || original.IT.Riuso.CodiceIPA != "pcm" || original.Supports != nil {
+		t.Errorf("the original PublicCode was modified: %+v", original)
+	}
+}
+
+func TestUpgradeRemovesWarnings(t *testing.T) {
+	files, err := filepath.Glob("testdata/v0/valid_with_warnings/*.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files = append(files, "testdata/v0/valid_with_warnings/no-network/authorsFile.yml")
+
+	p, err := NewParser(ParserConfig{DisableExternalChecks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range files {
+		// There's no organisation to move legal.repoOwner to.
+		if filepath.Base(file) == "valid_with_legal_repoOwner.yml" {
+			continue
+		}
+
+		t.Run(file, func(t *testing.T) {
+			pc, err := p.Parse(file)
+			if hasErrors(err) {
+				t.Fatalf("unexpected errors: %v", err)
+			}
+
+			upgraded, changes := Upgrade(pc)
+			if len(changes) == 0 {
+				t.Error("expected some changes")
+			}
+
+			out, err := upgraded.ToYAML()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := p.ParseStream(bytes.NewReader(out)); err != nil {
+				t.Errorf("unexpected results after the upgrade: %v", err)
+			}
+
+			if _, changes := Upgrade(upgraded); len(changes) != 0 {
+				t.Errorf("unexpected changes upgrading again: %v", changes)
+			}
+		})
+	}
+}
+
+func TestUpgradeRepoOwner(t *testing.T) {
+	name := "Comune di Roma"
+	other := "Roma Capitale"
+
+	pc := PublicCodeV0{PubliccodeYamlVersion: "0", Organisation: &OrganisationV0{URI: "urn:x-italian-pa:c_h501"}}
+	pc.Legal.RepoOwner = &name
+
+	upgraded, changes := Upgrade(pc)
+
+	expected := []Change{{
+		Kind: ChangeMoved, Key: "legal.repoOwner", To: "organisation.name", Old: name, New: name,
+		Description: "moved to 'organisation.name'",
+	}}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("wrong changes:\n%v\n- instead of:\n%v", changes, expected)
+	}
+
+	v0 := upgraded.(PublicCodeV0)
+	if v0.Legal.RepoOwner != nil || v0.Organisation.Name == nil || *v0.Organisation.Name != name {
+		t.Errorf("legal.repoOwner not moved: %+v", v0)
+	}
+
+	if pc.Organisation.Name != nil {
+		t.Errorf("the original organisation was modified: %+v", pc.Organisation)
+	}
+
+	// A different organisation.name wins.
+	pc.Organisation.Name = &other
+
+	upgraded, changes = Upgrade(pc)
+	if len(changes) != 1 || changes[0].Kind != ChangeRemoved {
+		t.Errorf("unexpected changes: %v", changes)
+	}
+
+	if v0 := upgraded.(PublicCodeV0); *v0.Organisation.Name != other {
+		t.Errorf("organisation.name overwritten: %s", *v0.Organisation.Name)
+	}
+}
+
+func hasErrors(err error) bool {
+	var vr ValidationResults
+	if err == nil || !errors.As(err, &vr) {
+		return err != nil
+	}
+
+	for _, result := range vr {
+		if _, ok := result.(ValidationError); ok {
+			return true
+		}
+	}
+
+	return false
+}
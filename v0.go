@@ -51,23 +51,11 @@ type PublicCodeV0 struct {
 
 	Description map[string]DescV0 `json:"description" validate:"gt=0,bcp47_keys,dive" yaml:"description"`
 
-	Legal struct {
-		License            string  `json:"license"                      validate:"required,is_spdx_expression" yaml:"license"`
-		MainCopyrightOwner *string `json:"mainCopyrightOwner,omitempty" yaml:"mainCopyrightOwner,omitempty"`
-		RepoOwner          *string `json:"repoOwner,omitempty"          yaml:"repoOwner,omitempty"`
-		AuthorsFile        *string `json:"authorsFile,omitempty"        yaml:"authorsFile,omitempty"`
-	} `yaml:"legal" json:"legal"`
-
-	Maintenance struct {
-		Type        string          `json:"type"                  validate:"required,oneof=internal contract community none"                        yaml:"type"`
-		Contractors *[]ContractorV0 `json:"contractors,omitempty" validate:"required_if=Type contract,excluded_unless=Type contract,omitempty,dive" yaml:"contractors,omitempty"`
-		Contacts    *[]ContactV0    `json:"contacts,omitempty"    validate:"required_if=Type community,required_if=Type internal,omitempty,dive"    yaml:"contacts,omitempty"`
-	} `yaml:"maintenance" json:"maintenance"`
-
-	Localisation struct {
-		LocalisationReady  *bool    `json:"localisationReady"  validate:"required"                                     yaml:"localisationReady"`
-		AvailableLanguages []string `json:"availableLanguages" validate:"required,gt=0,dive,bcp47_strict_language_tag" yaml:"availableLanguages"`
-	} `yaml:"localisation" json:"localisation"`
+	Legal LegalV0 `yaml:"legal" json:"legal"`
+
+	Maintenance MaintenanceV0 `yaml:"maintenance" json:"maintenance"`
+
+	Localisation LocalisationV0 `yaml:"localisation" json:"localisation"`
 
 	DependsOn *struct {
 		Open        *[]DependencyV0 `json:"open,omitempty"        validate:"omitempty,dive" yaml:"open,omitempty"`
@@ -96,6 +84,27 @@ type DescV0 struct {
 	Awards           []string  `json:"awards,omitempty"           yaml:"awards,omitempty"`
 }
 
+// LegalV0 is the legal information of the software.
+type LegalV0 struct {
+	License            string  `json:"license"                      validate:"required,is_spdx_expression" yaml:"license"`
+	MainCopyrightOwner *string `json:"mainCopyrightOwner,omitempty" yaml:"mainCopyrightOwner,omitempty"`
+	RepoOwner          *string `json:"repoOwner,omitempty"          yaml:"repoOwner,omitempty"`
+	AuthorsFile        *string `json:"authorsFile,omitempty"        yaml:"authorsFile,omitempty"`
+}
+
+// MaintenanceV0 describes how the software is maintained.
+type MaintenanceV0 struct {
+	Type        string          `json:"type"                  validate:"required,oneof=internal contract community none"                        yaml:"type"`
+	Contractors *[]ContractorV0 `json:"contractors,omitempty" validate:"required_if=Type contract,excluded_unless=Type contract,omitempty,dive" yaml:"contractors,omitempty"`
+	Contacts    *[]ContactV0    `json:"contacts,omitempty"    validate:"required_if=Type community,required_if=Type internal,omitempty,dive"    yaml:"contacts,omitempty"`
+}
+
+// LocalisationV0 describes the localisation of the software.
+type LocalisationV0 struct {
+	LocalisationReady  *bool    `json:"localisationReady"  validate:"required"                                     yaml:"localisationReady"`
+	AvailableLanguages []string `json:"availableLanguages" validate:"required,gt=0,dive,bcp47_strict_language_tag" yaml:"availableLanguages"`
+}
+
 // ContractorV0 is an entity or entities, if any, that are currently contracted for maintaining the software.
 type ContractorV0 struct {
 	Name    string  `json:"name"              validate:"required"             yaml:"name"`
@@ -15,7 +15,7 @@ func TestValidTestcasesV0_NoNetwork(t *testing.T) {
 func TestValidWithWarningTestcasesV0_NoNetwork(t *testing.T) {
 	expected := map[string]error{
 		"authorsFile.yml": ValidationResults{
-			ValidationWarning{"legal.authorsFile", "This key is DEPRECATED and will be removed in the future. It's safe to drop it", 71, 3},
+			ValidationWarning{Key: "legal.authorsFile", Description: "This key is DEPRECATED and will be removed in the future. It's safe to drop it", Line: 71, Column: 3, EndLine: 71, EndColumn: 29, Code: "PC-DEPRECATED-KEY"},
 		},
 	}
 
@@ -42,49 +42,58 @@ func TestInvalidTestcasesV0_NoNetwork(t *testing.T) {
 	expected := map[string]error{
 		// logo
 		"logo_missing_file.yml": ValidationResults{
-			ValidationError{"logo", "no such file: " + cwd + "/testdata/v0/invalid/no-network/no_such_file.png", 18, 1},
+			ValidationError{Key: "logo", Description: "no such file: " + cwd + "/testdata/v0/invalid/no-network/no_such_file.png", Line: 18, Column: 1, EndLine: 18, EndColumn: 24, Code: "PC-INVALID-LOGO"},
 		},
 		"logo_invalid_png.yml": ValidationResults{
-			ValidationError{"logo", "image: unknown format", 18, 1},
+			ValidationError{Key: "logo", Description: "image: unknown format", Line: 18, Column: 1, EndLine: 18, EndColumn: 35, Code: "PC-INVALID-LOGO"},
 		},
 
 		// landingURL
 		"landingURL_invalid.yml": ValidationResults{
 			// Just a syntax check here, no check for reachability as network is disabled
-			ValidationError{"landingURL", "landingURL must be an HTTP URL", 8, 1},
+			ValidationError{Key: "landingURL", Description: "landingURL must be an HTTP URL", Line: 8, Column: 1, EndLine: 8, EndColumn: 17, Code: "PC-INVALID-VALUE"},
 		},
 
 		// monochromeLogo
 		"monochromeLogo_invalid_png.yml": ValidationResults{
-			ValidationWarning{"monochromeLogo", "This key is DEPRECATED and will be removed in the future. Use 'logo' instead", 18, 1},
-			ValidationError{"monochromeLogo", "image: unknown format", 18, 1},
+			ValidationWarning{Key: "monochromeLogo", Description: "This key is DEPRECATED and will be removed in the future. Use 'logo' instead", Line: 18, Column: 1, EndLine: 18, EndColumn: 45, Code: "PC-DEPRECATED-KEY"},
+			ValidationError{Key: "monochromeLogo", Description: "image: unknown format", Line: 18, Column: 1, EndLine: 18, EndColumn: 45, Code: "PC-INVALID-LOGO"},
 		},
 
 		// YAML 1.1 boolean aliases must be rejected
 		"localisation_localisationReady_yaml11_yes.yml": ValidationResults{
-			ValidationError{"localisation.localisationReady", "wrong type for this field", 51, 1},
-			ValidationError{"localisation.localisationReady", "localisationReady is a required field", 51, 3},
-			ValidationError{"localisation.availableLanguages", "availableLanguages is a required field", 52, 3},
+			ValidationError{Key: "localisation.localisationReady", Description: "wrong type for this field", Line: 51, Column: 22, EndLine: 51, EndColumn: 24, Code: "PC-WRONG-TYPE"},
 		},
 		"localisation_localisationReady_yaml11_no.yml": ValidationResults{
-			ValidationError{"localisation.localisationReady", "wrong type for this field", 51, 1},
-			ValidationError{"localisation.localisationReady", "localisationReady is a required field", 51, 3},
-			ValidationError{"localisation.availableLanguages", "availableLanguages is a required field", 52, 3},
+			ValidationError{Key: "localisation.localisationReady", Description: "wrong type for this field", Line: 51, Column: 22, EndLine: 51, EndColumn: 23, Code: "PC-WRONG-TYPE"},
 		},
 		"localisation_localisationReady_yaml11_on.yml": ValidationResults{
-			ValidationError{"localisation.localisationReady", "wrong type for this field", 51, 1},
-			ValidationError{"localisation.localisationReady", "localisationReady is a required field", 51, 3},
-			ValidationError{"localisation.availableLanguages", "availableLanguages is a required field", 52, 3},
+			ValidationError{Key: "localisation.localisationReady", Description: "wrong type for this field", Line: 51, Column: 22, EndLine: 51, EndColumn: 23, Code: "PC-WRONG-TYPE"},
 		},
 		"localisation_localisationReady_yaml11_off.yml": ValidationResults{
-			ValidationError{"localisation.localisationReady", "wrong type for this field", 51, 1},
-			ValidationError{"localisation.localisationReady", "localisationReady is a required field", 51, 3},
-			ValidationError{"localisation.availableLanguages", "availableLanguages is a required field", 52, 3},
+			ValidationError{Key: "localisation.localisationReady", Description: "wrong type for this field", Line: 51, Column: 22, EndLine: 51, EndColumn: 24, Code: "PC-WRONG-TYPE"},
 		},
 
 		// supports
 		"supports_unknown_alias.yml": ValidationResults{
-			ValidationError{"supports[0].id", "id contains an unknown alias (see https://github.com/publiccodeyml/publiccode.yml/blob/main/docs/standard/aliases-list.rst)", 17, 5},
+			ValidationError{Key: "supports[0].id", Description: "id contains an unknown alias (see https://github.com/publiccodeyml/publiccode.yml/blob/main/docs/standard/aliases-list.rst)", Line: 17, Column: 5, EndLine: 17, EndColumn: 25, Code: "PC-INVALID-VALUE"},
+		},
+
+		// Typos, with "did you mean" suggestions
+		"developmentStatus_typo.yml": ValidationResults{
+			ValidationError{Key: "developmentStatus", Description: "developmentStatus must be one of the following: \"concept\", \"development\", \"beta\", \"stable\" or \"obsolete\", did you mean 'stable'?", Line: 26, Column: 1, EndLine: 26, EndColumn: 25, Code: "PC-INVALID-VALUE"},
+		},
+		"categories_typo.yml": ValidationResults{
+			ValidationError{Key: "categorie", Description: "unknown field \"categorie\", did you mean 'categories'?", Line: 17, Column: 1, EndLine: 17, EndColumn: 9, Code: "PC-UNKNOWN-FIELD"},
+		},
+		"categories_misspelled.yml": ValidationResults{
+			ValidationError{Key: "categories[0]", Description: "categories[0] must be a valid category, did you mean 'cloud-management'? (see https://github.com/publiccodeyml/publiccode.yml/blob/main/docs/standard/categories-list.rst)", Line: 18, Column: 5, EndLine: 18, EndColumn: 19, Code: "PC-INVALID-VALUE"},
+		},
+		"multiple_decode_errors.yml": ValidationResults{
+			ValidationError{Key: "softwareVersion", Description: "wrong type for this field", Line: 10, Column: 18, EndLine: 10, EndColumn: 24, Code: "PC-WRONG-TYPE"},
+			ValidationError{Key: "maintenance.contractors[0].webiste", Description: "unknown field \"webiste\", did you mean 'website'?", Line: 77, Column: 7, EndLine: 77, EndColumn: 13, Code: "PC-UNKNOWN-FIELD"},
+			ValidationError{Key: "localisation.localisationReady", Description: "wrong type for this field", Line: 95, Column: 22, EndLine: 95, EndColumn: 27, Code: "PC-WRONG-TYPE"},
+			ValidationError{Key: "applicationSuit", Description: "unknown field \"applicationSuit\", did you mean 'applicationSuite'?", Line: 5, Column: 1, EndLine: 5, EndColumn: 15, Code: "PC-UNKNOWN-FIELD"},
 		},
 	}
 
@@ -111,470 +120,473 @@ func TestInvalidTestcasesV0_NoNetwork(t *testing.T) {
 func TestInvalidTestcasesV0(t *testing.T) {
 	expected := map[string]error{
 		// publiccodeYmlVersion
-		"publiccodeYmlVersion_missing.yml": ValidationResults{ValidationError{"publiccodeYmlVersion", "publiccodeYmlVersion is a required field", 0, 0}},
+		"publiccodeYmlVersion_missing.yml": ValidationResults{ValidationError{Key: "publiccodeYmlVersion", Description: "publiccodeYmlVersion is a required field", Code: "PC-INVALID-VALUE"}},
 		"publiccodeYmlVersion_invalid.yml": ValidationResults{
 			ValidationError{
-				"publiccodeYmlVersion",
-				"unsupported version: '1'. Supported versions: 0, 0.2, 0.2.0, 0.2.1, 0.2.2, 0.3, 0.3.0, 0.4, 0.4.0, 0.5.0, 0.5, 0.7.0, 0.7",
-				0,
-				0,
+				Key:         "publiccodeYmlVersion",
+				Description: "unsupported version: '1'. Supported versions: 0, 0.2, 0.2.0, 0.2.1, 0.2.2, 0.3, 0.3.0, 0.4, 0.4.0, 0.5.0, 0.5, 0.7.0, 0.7",
+				Code:        "PC-UNSUPPORTED-VERSION",
 			},
 		},
 		"publiccodeYmlVersion_wrong_type.yml": ValidationResults{
-			ValidationError{"publiccodeYmlVersion", "wrong type for this field", 2, 1},
+			ValidationError{Key: "publiccodeYmlVersion", Description: "wrong type for this field", Line: 2, Column: 1, EndLine: 2, EndColumn: 24, Code: "PC-WRONG-TYPE"},
 		},
 
 		// name
-		"name_missing.yml": ValidationResults{ValidationError{"name", "name is a required field", 1, 1}},
-		"name_nil.yml":     ValidationResults{ValidationError{"name", "name is a required field", 4, 1}},
+		"name_missing.yml": ValidationResults{ValidationError{Key: "name", Description: "name is a required field", Line: 1, Column: 1, EndLine: 1, EndColumn: 20, Code: "PC-INVALID-VALUE"}},
+		"name_nil.yml":     ValidationResults{ValidationError{Key: "name", Description: "name is a required field", Line: 4, Column: 1, EndLine: 4, EndColumn: 9, Code: "PC-INVALID-VALUE"}},
 		"name_wrong_type.yml": ValidationResults{
-			ValidationError{"name", "wrong type for this field", 4, 1},
-			ValidationError{"name", "name is a required field", 4, 1},
+			ValidationError{Key: "name", Description: "wrong type for this field", Line: 4, Column: 7, EndLine: 4, EndColumn: 8, Code: "PC-WRONG-TYPE"},
 		},
 
 		// applicationSuite
-		"applicationSuite_wrong_type.yml": ValidationResults{ValidationError{"applicationSuite", "wrong type for this field", 4, 1}},
+		"applicationSuite_wrong_type.yml": ValidationResults{ValidationError{Key: "applicationSuite", Description: "wrong type for this field", Line: 4, Column: 19, EndLine: 4, EndColumn: 20, Code: "PC-WRONG-TYPE"}},
 
 		// url
-		"url_missing.yml": ValidationResults{ValidationError{"url", "url is a required field", 1, 1}},
+		"url_missing.yml": ValidationResults{ValidationError{Key: "url", Description: "url is a required field", Line: 1, Column: 1, EndLine: 1, EndColumn: 20, Code: "PC-INVALID-VALUE"}},
 		"url_wrong_type.yml": ValidationResults{
-			ValidationError{"url", "wrong type for this field", 6, 1},
-			ValidationError{"url", "url is a required field", 6, 1},
+			ValidationError{Key: "url", Description: "wrong type for this field", Line: 6, Column: 6, EndLine: 6, EndColumn: 7, Code: "PC-WRONG-TYPE"},
 		},
 		"url_invalid.yml": ValidationResults{
-			ValidationError{"url", "url must be a valid URL", 6, 1},
-			ValidationError{"url", "'foobar' not reachable: missing URL scheme", 6, 1},
-			ValidationError{"url", "is not a valid code repository", 6, 1},
+			ValidationError{Key: "url", Description: "url must be a valid URL", Line: 6, Column: 1, EndLine: 6, EndColumn: 13, Code: "PC-INVALID-VALUE"},
+			ValidationError{Key: "url", Description: "'foobar' not reachable: missing URL scheme", Line: 6, Column: 1, EndLine: 6, EndColumn: 13, Code: "PC-URL-UNREACHABLE"},
+			ValidationError{Key: "url", Description: "is not a valid code repository", Line: 6, Column: 1, EndLine: 6, EndColumn: 13, Code: "PC-INVALID-REPOSITORY"},
 		},
 
 		// landingURL
 		"landingURL_wrong_type.yml": ValidationResults{
-			ValidationError{"landingURL", "wrong type for this field", 8, 1},
+			ValidationError{Key: "landingURL", Description: "wrong type for this field", Line: 8, Column: 13, EndLine: 8, EndColumn: 14, Code: "PC-WRONG-TYPE"},
 		},
 		"landingURL_invalid.yml": ValidationResults{
-			ValidationError{"landingURL", "landingURL must be an HTTP URL", 8, 1},
-			ValidationError{"landingURL", "'???' not reachable: missing URL scheme", 8, 1},
+			ValidationError{Key: "landingURL", Description: "landingURL must be an HTTP URL", Line: 8, Column: 1, EndLine: 8, EndColumn: 17, Code: "PC-INVALID-VALUE"},
+			ValidationError{Key: "landingURL", Description: "'???' not reachable: missing URL scheme", Line: 8, Column: 1, EndLine: 8, EndColumn: 17, Code: "PC-URL-UNREACHABLE"},
 		},
 
 		// isBasedOn
 		"isBasedOn_wrong_type.yml": ValidationResults{
-			ValidationError{"isBasedOn.foobar", "wrong type for this field", 10, 1},
+			ValidationError{Key: "isBasedOn.foobar", Description: "wrong type for this field", Line: 10, Column: 11, EndLine: 10, EndColumn: 15, Code: "PC-WRONG-TYPE"},
 		},
 		"isBasedOn_bad_url_array.yml": ValidationResults{
-			ValidationError{"isBasedOn[1]", "isBasedOn[1] must be a valid URL", 11, 5},
+			ValidationError{Key: "isBasedOn[1]", Description: "isBasedOn[1] must be a valid URL", Line: 11, Column: 5, EndLine: 11, EndColumn: 10, Code: "PC-INVALID-VALUE"},
 		},
 		"isBasedOn_bad_url_string.yml": ValidationResults{
-			ValidationError{"isBasedOn[0]", "isBasedOn[0] must be a valid URL", 9, 1},
+			ValidationError{Key: "isBasedOn[0]", Description: "isBasedOn[0] must be a valid URL", Line: 9, Column: 1, EndLine: 9, EndColumn: 9, Code: "PC-INVALID-VALUE"},
 		},
 
 		// softwareVersion
 		"softwareVersion_wrong_type.yml": ValidationResults{
-			ValidationError{"softwareVersion", "wrong type for this field", 8, 1},
+			ValidationError{Key: "softwareVersion", Description: "wrong type for this field", Line: 8, Column: 18, EndLine: 8, EndColumn: 19, Code: "PC-WRONG-TYPE"},
 		},
 
 		// releaseDate
-		"releaseDate_empty.yml": ValidationResults{ValidationError{"releaseDate", "releaseDate must be a date with format 'YYYY-MM-DD'", 8, 1}},
+		"releaseDate_empty.yml": ValidationResults{ValidationError{Key: "releaseDate", Description: "releaseDate must be a date with format 'YYYY-MM-DD'", Line: 8, Column: 1, EndLine: 8, EndColumn: 15, Code: "PC-INVALID-VALUE"}},
 		"releaseDate_wrong_type.yml": ValidationResults{
-			ValidationError{"releaseDate", "wrong type for this field", 8, 1},
+			ValidationError{Key: "releaseDate", Description: "wrong type for this field", Line: 8, Column: 14, EndLine: 8, EndColumn: 15, Code: "PC-WRONG-TYPE"},
 		},
 		"releaseDate_invalid.yml": ValidationResults{
-			ValidationError{"releaseDate", "releaseDate must be a date with format 'YYYY-MM-DD'", 8, 1},
+			ValidationError{Key: "releaseDate", Description: "releaseDate must be a date with format 'YYYY-MM-DD'", Line: 8, Column: 1, EndLine: 8, EndColumn: 21, Code: "PC-INVALID-VALUE"},
 		},
 		"releaseDate_datetime.yml": ValidationResults{
-			ValidationError{"releaseDate", "releaseDate must be a date with format 'YYYY-MM-DD'", 8, 1},
+			ValidationError{Key: "releaseDate", Description: "releaseDate must be a date with format 'YYYY-MM-DD'", Line: 8, Column: 1, EndLine: 8, EndColumn: 34, Code: "PC-INVALID-VALUE"},
 		},
 
 		// logo
 		"logo_wrong_type.yml": ValidationResults{
-			ValidationError{"logo", "wrong type for this field", 18, 1},
+			ValidationError{Key: "logo", Description: "wrong type for this field", Line: 18, Column: 7, EndLine: 18, EndColumn: 8, Code: "PC-WRONG-TYPE"},
 		},
 		"logo_unsupported_extension.yml": ValidationResults{
-			ValidationError{"logo", "invalid file extension for: " + cwd + "/testdata/v0/invalid/logo.mpg", 18, 1},
+			ValidationError{Key: "logo", Description: "invalid file extension for: " + cwd + "/testdata/v0/invalid/logo.mpg", Line: 18, Column: 1, EndLine: 18, EndColumn: 16, Code: "PC-INVALID-LOGO"},
 		},
 		"logo_missing_file.yml": ValidationResults{
-			ValidationError{"logo", "no such file: " + cwd + "/testdata/v0/invalid/no_such_file.png", 18, 1},
+			ValidationError{Key: "logo", Description: "no such file: " + cwd + "/testdata/v0/invalid/no_such_file.png", Line: 18, Column: 1, EndLine: 18, EndColumn: 24, Code: "PC-INVALID-LOGO"},
 		},
 		"logo_absolute_path.yml": ValidationResults{
-			ValidationError{"logo", "is an absolute path. Only relative paths or HTTP(s) URLs allowed", 18, 1},
+			ValidationError{Key: "logo", Description: "is an absolute path. Only relative paths or HTTP(s) URLs allowed", Line: 18, Column: 1, EndLine: 18, EndColumn: 17, Code: "PC-INVALID-PATH"},
 		},
 		"logo_file_scheme.yml": ValidationResults{
-			ValidationError{"logo", "is a file:// URL. Only relative paths or HTTP(s) URLs allowed", 18, 1},
+			ValidationError{Key: "logo", Description: "is a file:// URL. Only relative paths or HTTP(s) URLs allowed", Line: 18, Column: 1, EndLine: 18, EndColumn: 24, Code: "PC-INVALID-PATH"},
 		},
 		"logo_file_scheme2.yml": ValidationResults{
-			ValidationError{"logo", "is a file:// URL. Only relative paths or HTTP(s) URLs allowed", 18, 1},
+			ValidationError{Key: "logo", Description: "is a file:// URL. Only relative paths or HTTP(s) URLs allowed", Line: 18, Column: 1, EndLine: 18, EndColumn: 23, Code: "PC-INVALID-PATH"},
 		},
 		"logo_file_scheme3.yml": ValidationResults{
-			ValidationError{"logo", "is a file:// URL. Only relative paths or HTTP(s) URLs allowed", 18, 1},
+			ValidationError{Key: "logo", Description: "is a file:// URL. Only relative paths or HTTP(s) URLs allowed", Line: 18, Column: 1, EndLine: 18, EndColumn: 22, Code: "PC-INVALID-PATH"},
 		},
 		// Local publiccode.yml and URL in logo: should look for the logo remotely
 		"logo_missing_url.yml": ValidationResults{
-			ValidationError{"logo", "HTTP GET failed for https://google.com/no_such_file.png: not found", 18, 1},
+			ValidationError{Key: "logo", Description: "HTTP GET failed for https://google.com/no_such_file.png: not found", Line: 18, Column: 1, EndLine: 18, EndColumn: 43, Code: "PC-INVALID-LOGO"},
 		},
 
 		// monochromeLogo
 		"monochromeLogo_wrong_type.yml": ValidationResults{
-			ValidationError{"monochromeLogo", "wrong type for this field", 18, 1},
+			ValidationError{Key: "monochromeLogo", Description: "wrong type for this field", Line: 18, Column: 17, EndLine: 18, EndColumn: 18, Code: "PC-WRONG-TYPE"},
 		},
 		"monochromeLogo_unsupported_extension.yml": ValidationResults{
-			ValidationWarning{"monochromeLogo", "This key is DEPRECATED and will be removed in the future. Use 'logo' instead", 18, 1},
+			ValidationWarning{Key: "monochromeLogo", Description: "This key is DEPRECATED and will be removed in the future. Use 'logo' instead", Line: 18, Column: 1, EndLine: 18, EndColumn: 36, Code: "PC-DEPRECATED-KEY"},
 			ValidationError{
-				"monochromeLogo",
-				"invalid file extension for: " + cwd + "/testdata/v0/invalid/monochromeLogo.mpg",
-				18,
-				1,
+				Key:         "monochromeLogo",
+				Description: "invalid file extension for: " + cwd + "/testdata/v0/invalid/monochromeLogo.mpg",
+				Line:        18,
+				Column:      1,
+				EndLine:     18,
+				EndColumn:   36,
+				Code:        "PC-INVALID-LOGO",
 			},
 		},
 		"monochromeLogo_missing_file.yml": ValidationResults{
-			ValidationWarning{"monochromeLogo", "This key is DEPRECATED and will be removed in the future. Use 'logo' instead", 18, 1},
-			ValidationError{"monochromeLogo", "no such file: " + cwd + "/testdata/v0/invalid/no_such_file.png", 18, 1},
+			ValidationWarning{Key: "monochromeLogo", Description: "This key is DEPRECATED and will be removed in the future. Use 'logo' instead", Line: 18, Column: 1, EndLine: 18, EndColumn: 34, Code: "PC-DEPRECATED-KEY"},
+			ValidationError{Key: "monochromeLogo", Description: "no such file: " + cwd + "/testdata/v0/invalid/no_such_file.png", Line: 18, Column: 1, EndLine: 18, EndColumn: 34, Code: "PC-INVALID-LOGO"},
 		},
 
 		// organisation
 		"organisation_wrong_uri.yml": ValidationResults{
-			ValidationError{"organisation.uri", "uri is not a valid URI", 19, 3},
+			ValidationError{Key: "organisation.uri", Description: "uri is not a valid URI", Line: 19, Column: 3, EndLine: 19, EndColumn: 10, Code: "PC-INVALID-VALUE"},
 		},
 		"organisation_wrong_type.yml": ValidationResults{
-			ValidationError{"organisation[0]", "wrong type for this field", 18, 1},
+			ValidationError{Key: "organisation[0]", Description: "wrong type for this field", Line: 18, Column: 3, EndLine: 19, EndColumn: 22, Code: "PC-WRONG-TYPE"},
 		},
 		"organisation_uri_missing.yml": ValidationResults{
-			ValidationError{"organisation.uri", "uri is a required field", 18, 3},
+			ValidationError{Key: "organisation.uri", Description: "uri is a required field", Line: 18, Column: 3, EndLine: 18, EndColumn: 6, Code: "PC-INVALID-VALUE"},
 		},
 		"organisation_uri_wrong_italian_pa.yml": ValidationResults{
-			ValidationError{"organisation.uri", "uri is not a valid URI", 20, 3},
+			ValidationError{Key: "organisation.uri", Description: "uri is not a valid URI", Line: 20, Column: 3, EndLine: 20, EndColumn: 10, Code: "PC-INVALID-VALUE"},
 		},
 		"organisation_uri_wrong_italian_pa2.yml": ValidationResults{
-			ValidationError{"organisation.uri", "uri must be a valid Italian Public Administration Code (iPA) with format 'urn:x-italian-pa:[codiceIPA]' (see https://github.com/publiccodeyml/italian-organizations-ipa-vocabulary)", 19, 3},
+			ValidationError{Key: "organisation.uri", Description: "uri must be a valid Italian Public Administration Code (iPA) with format 'urn:x-italian-pa:[codiceIPA]' (see https://github.com/publiccodeyml/italian-organizations-ipa-vocabulary)", Line: 19, Column: 3, EndLine: 19, EndColumn: 34, Code: "PC-INVALID-VALUE"},
 		},
 
 		// inputTypes
 		"inputTypes_invalid.yml": ValidationResults{
-			ValidationError{"inputTypes[1]", "inputTypes[1] is not a valid MIME type", 16, 7},
-			ValidationWarning{"inputTypes", "This key is DEPRECATED and will be removed in the future. It's safe to drop it", 14, 1},
+			ValidationError{Key: "inputTypes[1]", Description: "inputTypes[1] is not a valid MIME type", Line: 16, Column: 7, EndLine: 16, EndColumn: 12, Code: "PC-INVALID-VALUE"},
+			ValidationWarning{Key: "inputTypes", Description: "This key is DEPRECATED and will be removed in the future. It's safe to drop it", Line: 14, Column: 1, EndLine: 14, EndColumn: 10, Code: "PC-DEPRECATED-KEY"},
 		},
 		"inputTypes_wrong_type.yml": ValidationResults{
-			ValidationError{"inputTypes.foobar", "wrong type for this field", 15, 1},
+			ValidationError{Key: "inputTypes.foobar", Description: "wrong type for this field", Line: 15, Column: 11, EndLine: 15, EndColumn: 15, Code: "PC-WRONG-TYPE"},
 		},
 
 		// outputTypes
 		"outputTypes_invalid.yml": ValidationResults{
-			ValidationError{"outputTypes[1]", "outputTypes[1] is not a valid MIME type", 16, 7},
-			ValidationWarning{"outputTypes", "This key is DEPRECATED and will be removed in the future. It's safe to drop it", 14, 1},
+			ValidationError{Key: "outputTypes[1]", Description: "outputTypes[1] is not a valid MIME type", Line: 16, Column: 7, EndLine: 16, EndColumn: 12, Code: "PC-INVALID-VALUE"},
+			ValidationWarning{Key: "outputTypes", Description: "This key is DEPRECATED and will be removed in the future. It's safe to drop it", Line: 14, Column: 1, EndLine: 14, EndColumn: 11, Code: "PC-DEPRECATED-KEY"},
 		},
 		"outputTypes_wrong_type.yml": ValidationResults{
-			ValidationError{"outputTypes.foobar", "wrong type for this field", 15, 1},
+			ValidationError{Key: "outputTypes.foobar", Description: "wrong type for this field", Line: 15, Column: 11, EndLine: 15, EndColumn: 15, Code: "PC-WRONG-TYPE"},
 		},
 
 		// platforms
-		"platforms_missing.yml": ValidationResults{ValidationError{"platforms", "platforms must contain more than 0 items", 1, 1}},
+		"platforms_missing.yml": ValidationResults{ValidationError{Key: "platforms", Description: "platforms must contain more than 0 items", Line: 1, Column: 1, EndLine: 1, EndColumn: 20, Code: "PC-INVALID-VALUE"}},
 		"platforms_wrong_type.yml": ValidationResults{
-			ValidationError{"platforms", "wrong type for this field", 9, 1},
-			ValidationError{"platforms", "platforms must contain more than 0 items", 9, 1},
+			ValidationError{Key: "platforms", Description: "wrong type for this field", Line: 9, Column: 12, EndLine: 9, EndColumn: 19, Code: "PC-WRONG-TYPE"},
 		},
 
 		// categories
-		"categories_invalid.yml": ValidationResults{ValidationError{"categories[0]", "categories[0] must be a valid category (see https://github.com/publiccodeyml/publiccode.yml/blob/main/docs/standard/categories-list.rst)", 13, 5}},
+		"categories_invalid.yml": ValidationResults{ValidationError{Key: "categories[0]", Description: "categories[0] must be a valid category (see https://github.com/publiccodeyml/publiccode.yml/blob/main/docs/standard/categories-list.rst)", Line: 13, Column: 5, EndLine: 13, EndColumn: 20, Code: "PC-INVALID-VALUE"}},
 
 		// usedBy
 		"usedBy_wrong_type.yml": ValidationResults{
-			ValidationError{"usedBy", "wrong type for this field", 14, 1},
+			ValidationError{Key: "usedBy", Description: "wrong type for this field", Line: 14, Column: 9, EndLine: 14, EndColumn: 16, Code: "PC-WRONG-TYPE"},
 		},
 
 		// fundedBy
 		"fundedBy_wrong_uri.yml": ValidationResults{
-			ValidationError{"fundedBy[0].uri", "uri is not a valid URI", 19, 5},
+			ValidationError{Key: "fundedBy[0].uri", Description: "uri is not a valid URI", Line: 19, Column: 5, EndLine: 19, EndColumn: 12, Code: "PC-INVALID-VALUE"},
 		},
 		"fundedBy_wrong_type.yml": ValidationResults{
-			ValidationError{"fundedBy.name", "wrong type for this field", 18, 1},
+			ValidationError{Key: "fundedBy.name", Description: "wrong type for this field", Line: 18, Column: 7, EndLine: 19, EndColumn: 20, Code: "PC-WRONG-TYPE"},
 		},
 		"fundedBy_uri_missing.yml": ValidationResults{
-			ValidationError{"fundedBy[0].uri", "uri is a required field", 18, 5},
+			ValidationError{Key: "fundedBy[0].uri", Description: "uri is a required field", Line: 18, Column: 5, EndLine: 18, EndColumn: 8, Code: "PC-INVALID-VALUE"},
 		},
 		"fundedBy_uri_wrong_italian_pa.yml": ValidationResults{
-			ValidationError{"fundedBy[0].uri", "uri is not a valid URI", 20, 5},
+			ValidationError{Key: "fundedBy[0].uri", Description: "uri is not a valid URI", Line: 20, Column: 5, EndLine: 20, EndColumn: 12, Code: "PC-INVALID-VALUE"},
 		},
 		"fundedBy_uri_wrong_italian_pa2.yml": ValidationResults{
-			ValidationError{"fundedBy[0].uri", "uri must be a valid Italian Public Administration Code (iPA) with format 'urn:x-italian-pa:[codiceIPA]' (see https://github.com/publiccodeyml/italian-organizations-ipa-vocabulary)", 19, 5},
+			ValidationError{Key: "fundedBy[0].uri", Description: "uri must be a valid Italian Public Administration Code (iPA) with format 'urn:x-italian-pa:[codiceIPA]' (see https://github.com/publiccodeyml/italian-organizations-ipa-vocabulary)", Line: 19, Column: 5, EndLine: 19, EndColumn: 36, Code: "PC-INVALID-VALUE"},
 		},
 
 		// roadmap
 		"roadmap_invalid.yml": ValidationResults{
-			ValidationError{"roadmap", "roadmap must be an HTTP URL", 4, 1},
-			ValidationError{"roadmap", "'foobar' not reachable: missing URL scheme", 4, 1},
+			ValidationError{Key: "roadmap", Description: "roadmap must be an HTTP URL", Line: 4, Column: 1, EndLine: 4, EndColumn: 17, Code: "PC-INVALID-VALUE"},
+			ValidationError{Key: "roadmap", Description: "'foobar' not reachable: missing URL scheme", Line: 4, Column: 1, EndLine: 4, EndColumn: 17, Code: "PC-URL-UNREACHABLE"},
 		},
 		"roadmap_wrong_type.yml": ValidationResults{
-			ValidationError{"roadmap", "wrong type for this field", 4, 1},
+			ValidationError{Key: "roadmap", Description: "wrong type for this field", Line: 4, Column: 10, EndLine: 4, EndColumn: 11, Code: "PC-WRONG-TYPE"},
 		},
 
 		// developmentStatus
 		"developmentStatus_missing.yml": ValidationResults{
-			ValidationError{"developmentStatus", "developmentStatus is a required field", 1, 1},
+			ValidationError{Key: "developmentStatus", Description: "developmentStatus is a required field", Line: 1, Column: 1, EndLine: 1, EndColumn: 20, Code: "PC-INVALID-VALUE"},
 		},
 		"developmentStatus_invalid.yml": ValidationResults{
-			ValidationError{"developmentStatus", "developmentStatus must be one of the following: \"concept\", \"development\", \"beta\", \"stable\" or \"obsolete\"", 16, 1},
+			ValidationError{Key: "developmentStatus", Description: "developmentStatus must be one of the following: \"concept\", \"development\", \"beta\", \"stable\" or \"obsolete\"", Line: 16, Column: 1, EndLine: 16, EndColumn: 27, Code: "PC-INVALID-VALUE"},
 		},
 		"developmentStatus_wrong_type.yml": ValidationResults{
-			ValidationError{"developmentStatus", "wrong type for this field", 16, 1},
-			ValidationError{"developmentStatus", "developmentStatus is a required field", 16, 1},
+			ValidationError{Key: "developmentStatus", Description: "wrong type for this field", Line: 16, Column: 20, EndLine: 16, EndColumn: 21, Code: "PC-WRONG-TYPE"},
 		},
 
 		// softwareType
 		"softwareType_missing.yml": ValidationResults{
-			ValidationError{"softwareType", "softwareType is a required field", 1, 1},
+			ValidationError{Key: "softwareType", Description: "softwareType is a required field", Line: 1, Column: 1, EndLine: 1, EndColumn: 20, Code: "PC-INVALID-VALUE"},
 		},
 		"softwareType_invalid.yml": ValidationResults{
-			ValidationError{"softwareType", "softwareType must be one of the following: \"standalone/mobile\", \"standalone/iot\", \"standalone/desktop\", \"standalone/web\", \"standalone/backend\", \"standalone/other\", \"addon\", \"library\" or \"configurationFiles\"", 17, 1},
+			ValidationError{Key: "softwareType", Description: "softwareType must be one of the following: \"standalone/mobile\", \"standalone/iot\", \"standalone/desktop\", \"standalone/web\", \"standalone/backend\", \"standalone/other\", \"addon\", \"library\" or \"configurationFiles\"", Line: 17, Column: 1, EndLine: 17, EndColumn: 22, Code: "PC-INVALID-VALUE"},
 		},
 		"softwareType_wrong_type.yml": ValidationResults{
-			ValidationError{"softwareType", "wrong type for this field", 17, 1},
-			ValidationError{"softwareType", "softwareType is a required field", 17, 1},
+			ValidationError{Key: "softwareType", Description: "wrong type for this field", Line: 17, Column: 15, EndLine: 17, EndColumn: 16, Code: "PC-WRONG-TYPE"},
 		},
 
 		// intendedAudience
 		// intendedAudience.*
 		"intendedAudience_wrong_type.yml": ValidationResults{
-			ValidationError{"intendedAudience", "wrong type for this field", 18, 1},
+			ValidationError{Key: "intendedAudience", Description: "wrong type for this field", Line: 18, Column: 19, EndLine: 18, EndColumn: 20, Code: "PC-WRONG-TYPE"},
 		},
 		"intendedAudience_countries_invalid_country.yml": ValidationResults{
-			ValidationError{"intendedAudience.countries[2]", "countries[2] must be a valid ISO 3166-1 alpha-2 two-letter country code", 22, 7},
+			ValidationError{Key: "intendedAudience.countries[2]", Description: "countries[2] must be a valid ISO 3166-1 alpha-2 two-letter country code", Line: 22, Column: 7, EndLine: 22, EndColumn: 14, Code: "PC-INVALID-VALUE"},
 		},
 		"intendedAudience_countries_invalid_iso_3166_1_alpha_2.yml": ValidationResults{
-			ValidationError{"intendedAudience.countries[2]", "countries[2] must be a valid ISO 3166-1 alpha-2 two-letter country code", 22, 7},
+			ValidationError{Key: "intendedAudience.countries[2]", Description: "countries[2] must be a valid ISO 3166-1 alpha-2 two-letter country code", Line: 22, Column: 7, EndLine: 22, EndColumn: 8, Code: "PC-INVALID-VALUE"},
 		},
 		"intendedAudience_countries_wrong_type.yml": ValidationResults{
-			ValidationError{"intendedAudience.countries", "wrong type for this field", 19, 1},
+			ValidationError{Key: "intendedAudience.countries", Description: "wrong type for this field", Line: 19, Column: 16, EndLine: 19, EndColumn: 17, Code: "PC-WRONG-TYPE"},
 		},
 		"intendedAudience_unsupportedCountries_invalid_country.yml": ValidationResults{
-			ValidationError{"intendedAudience.unsupportedCountries[0]", "unsupportedCountries[0] must be a valid ISO 3166-1 alpha-2 two-letter country code", 20, 7},
+			ValidationError{Key: "intendedAudience.unsupportedCountries[0]", Description: "unsupportedCountries[0] must be a valid ISO 3166-1 alpha-2 two-letter country code", Line: 20, Column: 7, EndLine: 20, EndColumn: 14, Code: "PC-INVALID-VALUE"},
 		},
 		"intendedAudience_unsupportedCountries_wrong_type.yml": ValidationResults{
-			ValidationError{"intendedAudience.unsupportedCountries", "wrong type for this field", 19, 1},
+			ValidationError{Key: "intendedAudience.unsupportedCountries", Description: "wrong type for this field", Line: 19, Column: 25, EndLine: 19, EndColumn: 26, Code: "PC-WRONG-TYPE"},
 		},
 		"intendedAudience_scope_invalid_scope.yml": ValidationResults{
-			ValidationError{"intendedAudience.scope[0]", "scope[0] must be a valid scope (see https://github.com/publiccodeyml/publiccode.yml/blob/main/docs/standard/scope-list.rst)", 20, 9},
+			ValidationError{Key: "intendedAudience.scope[0]", Description: "scope[0] must be a valid scope (see https://github.com/publiccodeyml/publiccode.yml/blob/main/docs/standard/scope-list.rst)", Line: 20, Column: 9, EndLine: 20, EndColumn: 14, Code: "PC-INVALID-VALUE"},
 		},
 		"intendedAudience_scope_wrong_type.yml": ValidationResults{
-			ValidationError{"intendedAudience.scope", "wrong type for this field", 19, 1},
+			ValidationError{Key: "intendedAudience.scope", Description: "wrong type for this field", Line: 19, Column: 10, EndLine: 19, EndColumn: 11, Code: "PC-WRONG-TYPE"},
 		},
 
 		// description
 		// description.*
 		"description_invalid_language.yml": ValidationResults{
-			ValidationError{"description", "description must be a valid BCP 47 language", 18, 1},
+			ValidationError{Key: "description", Description: "description must be a valid BCP 47 language", Line: 18, Column: 1, EndLine: 18, EndColumn: 11, Code: "PC-INVALID-VALUE"},
 		},
 		"description_en_features_missing.yml": ValidationResults{
-			ValidationError{"description.en.features", "features must contain more than 0 items", 22, 5},
+			ValidationError{Key: "description.en.features", Description: "features must contain more than 0 items", Line: 22, Column: 5, EndLine: 22, EndColumn: 17, Code: "PC-INVALID-VALUE"},
 		},
 		"description_en_features_empty.yml": ValidationResults{
-			ValidationError{"description.en.features", "features must contain more than 0 items", 39, 5},
+			ValidationError{Key: "description.en.features", Description: "features must contain more than 0 items", Line: 39, Column: 5, EndLine: 39, EndColumn: 16, Code: "PC-INVALID-VALUE"},
 		},
 		"description_en_localisedName_wrong_type.yml": ValidationResults{
-			ValidationError{"description.en.localisedName", "wrong type for this field", 21, 1},
-			ValidationError{"description", "description must contain more than 0 items", 18, 1},
+			ValidationError{Key: "description.en.localisedName", Description: "wrong type for this field", Line: 21, Column: 20, EndLine: 21, EndColumn: 21, Code: "PC-WRONG-TYPE"},
 		},
 		"description_en_genericName_too_long.yml": ValidationResults{
-			ValidationError{"description.en.genericName", "genericName must be a maximum of 35 characters in length", 22, 5},
-			ValidationWarning{"description.en.genericName", "This key is DEPRECATED and will be removed in the future. It's safe to drop it", 22, 5},
+			ValidationError{Key: "description.en.genericName", Description: "genericName must be a maximum of 35 characters in length", Line: 22, Column: 5, EndLine: 22, EndColumn: 53, Code: "PC-INVALID-VALUE"},
+			ValidationWarning{Key: "description.en.genericName", Description: "This key is DEPRECATED and will be removed in the future. It's safe to drop it", Line: 22, Column: 5, EndLine: 22, EndColumn: 53, Code: "PC-DEPRECATED-KEY"},
 		},
 		"description_en_shortDescription_missing.yml": ValidationResults{
-			ValidationError{"description.en.shortDescription", "shortDescription is a required field", 20, 5},
+			ValidationError{Key: "description.en.shortDescription", Description: "shortDescription is a required field", Line: 20, Column: 5, EndLine: 20, EndColumn: 17, Code: "PC-INVALID-VALUE"},
 		},
 		"description_en_longDescription_missing.yml": ValidationResults{
-			ValidationError{"description.en.longDescription", "longDescription is a required field", 20, 5},
+			ValidationError{Key: "description.en.longDescription", Description: "longDescription is a required field", Line: 20, Column: 5, EndLine: 20, EndColumn: 17, Code: "PC-INVALID-VALUE"},
 		},
 		"description_en_longDescription_too_long.yml": ValidationResults{
-			ValidationError{"description.en.longDescription", "longDescription must be a maximum of 10000 characters in length", 27, 5},
+			ValidationError{Key: "description.en.longDescription", Description: "longDescription must be a maximum of 10000 characters in length", Line: 27, Column: 5, EndLine: 148, EndColumn: 9, Code: "PC-INVALID-VALUE"},
 		},
 		"description_en_longDescription_too_short.yml": ValidationResults{
-			ValidationError{"description.en.longDescription", "longDescription must be at least 150 characters in length", 27, 5},
+			ValidationError{Key: "description.en.longDescription", Description: "longDescription must be at least 150 characters in length", Line: 27, Column: 5, EndLine: 29, EndColumn: 47, Code: "PC-INVALID-VALUE"},
 		},
 		"description_en_longDescription_too_short_grapheme_clusters.yml": ValidationResults{
-			ValidationError{"description.en.longDescription", "longDescription must be at least 150 characters in length", 28, 5},
+			ValidationError{Key: "description.en.longDescription", Description: "longDescription must be at least 150 characters in length", Line: 28, Column: 5, EndLine: 41, EndColumn: 12, Code: "PC-INVALID-VALUE"},
 		},
 		"description_en_documentation_invalid.yml": ValidationResults{
-			ValidationError{"description.en.documentation", "documentation must be an HTTP URL", 25, 5},
-			ValidationError{"description.en.documentation", "'not_a_url' not reachable: missing URL scheme", 25, 5},
+			ValidationError{Key: "description.en.documentation", Description: "documentation must be an HTTP URL", Line: 25, Column: 5, EndLine: 25, EndColumn: 30, Code: "PC-INVALID-VALUE"},
+			ValidationError{Key: "description.en.documentation", Description: "'not_a_url' not reachable: missing URL scheme", Line: 25, Column: 5, EndLine: 25, EndColumn: 30, Code: "PC-URL-UNREACHABLE"},
 		},
 		"description_en_documentation_wrong_type.yml": ValidationResults{
-			ValidationError{"description.en.documentation", "wrong type for this field", 25, 1},
-			ValidationError{"description", "description must contain more than 0 items", 20, 1},
+			ValidationError{Key: "description.en.documentation", Description: "wrong type for this field", Line: 25, Column: 20, EndLine: 25, EndColumn: 21, Code: "PC-WRONG-TYPE"},
 		},
 		"description_en_apiDocumentation_invalid.yml": ValidationResults{
-			ValidationError{"description.en.apiDocumentation", "apiDocumentation must be an HTTP URL", 41, 5},
-			ValidationError{"description.en.apiDocumentation", "'abc' not reachable: missing URL scheme", 41, 5},
+			ValidationError{Key: "description.en.apiDocumentation", Description: "apiDocumentation must be an HTTP URL", Line: 41, Column: 5, EndLine: 41, EndColumn: 27, Code: "PC-INVALID-VALUE"},
+			ValidationError{Key: "description.en.apiDocumentation", Description: "'abc' not reachable: missing URL scheme", Line: 41, Column: 5, EndLine: 41, EndColumn: 27, Code: "PC-URL-UNREACHABLE"},
 		},
 		"description_en_apiDocumentation_wrong_type.yml": ValidationResults{
-			ValidationError{"description.en.apiDocumentation", "wrong type for this field", 43, 1},
-			ValidationError{"description", "description must contain more than 0 items", 20, 1},
+			ValidationError{Key: "description.en.apiDocumentation", Description: "wrong type for this field", Line: 43, Column: 23, EndLine: 43, EndColumn: 24, Code: "PC-WRONG-TYPE"},
 		},
 		"description_en_screenshots_missing_file.yml": ValidationResults{
 			ValidationError{
-				"description.en.screenshots[0]",
-				"'no_such_file.png' is not an image: no such file: " + cwd + "/testdata/v0/invalid/no_such_file.png",
-				42,
-				9,
+				Key:         "description.en.screenshots[0]",
+				Description: "'no_such_file.png' is not an image: no such file: " + cwd + "/testdata/v0/invalid/no_such_file.png",
+				Line:        42,
+				Column:      9,
+				EndLine:     42,
+				EndColumn:   24,
+				Code:        "PC-INVALID-IMAGE",
 			},
 		},
 		"description_en_awards_wrong_type.yml": ValidationResults{
-			ValidationError{"description.en.awards", "wrong type for this field", 40, 1},
-			ValidationError{"description", "description must contain more than 0 items", 18, 1},
+			ValidationError{Key: "description.en.awards", Description: "wrong type for this field", Line: 40, Column: 13, EndLine: 40, EndColumn: 14, Code: "PC-WRONG-TYPE"},
 		},
 		"description_en_videos_invalid.yml": ValidationResults{
-			ValidationError{"description.en.videos[0]", "videos[0] must be an HTTP URL", 41, 9},
-			ValidationError{"description.en.videos[0]", "'ABC' is not a valid video URL supporting oEmbed: invalid oEmbed link: ABC", 41, 9},
+			ValidationError{Key: "description.en.videos[0]", Description: "videos[0] must be an HTTP URL", Line: 41, Column: 9, EndLine: 41, EndColumn: 11, Code: "PC-INVALID-VALUE"},
+			ValidationError{Key: "description.en.videos[0]", Description: "'ABC' is not a valid video URL supporting oEmbed: invalid oEmbed link: ABC", Line: 41, Column: 9, EndLine: 41, EndColumn: 11, Code: "PC-INVALID-VIDEO"},
 		},
 		"description_en_videos_invalid_oembed.yml": ValidationResults{
-			ValidationError{"description.en.videos[0]", "'https://google.com' is not a valid video URL supporting oEmbed: invalid oEmbed link: https://google.com", 41, 9},
+			ValidationError{Key: "description.en.videos[0]", Description: "'https://google.com' is not a valid video URL supporting oEmbed: invalid oEmbed link: https://google.com", Line: 41, Column: 9, EndLine: 41, EndColumn: 26, Code: "PC-INVALID-VIDEO"},
 		},
 
 		// legal
 		// legal.*
-		"legal_missing.yml": ValidationResults{ValidationError{"legal.license", "license is a required field", 0, 0}},
+		"legal_missing.yml": ValidationResults{ValidationError{Key: "legal.license", Description: "license is a required field", Code: "PC-INVALID-VALUE"}},
 		"legal_wrong_type.yml": ValidationResults{
-			ValidationError{"legal", "wrong type for this field", 46, 1},
-			ValidationError{"legal.license", "license is a required field", 46, 8},
+			ValidationError{Key: "legal", Description: "wrong type for this field", Line: 46, Column: 8, EndLine: 46, EndColumn: 9, Code: "PC-WRONG-TYPE"},
 		},
-		"legal_license_missing.yml": ValidationResults{ValidationError{"legal.license", "license is a required field", 41, 3}},
+		"legal_license_missing.yml": ValidationResults{ValidationError{Key: "legal.license", Description: "license is a required field", Line: 41, Column: 3, EndLine: 41, EndColumn: 20, Code: "PC-INVALID-VALUE"}},
 		"legal_license_invalid.yml": ValidationResults{ValidationError{
-			"legal.license", "license must be a valid license (see https://spdx.org/licenses)", 42, 3,
+			Key: "legal.license", Description: "license must be a valid license (see https://spdx.org/licenses)", Line: 42, Column: 3,
+			EndLine:   42,
+			EndColumn: 26,
+			Code:      "PC-INVALID-VALUE",
 		}},
 		"legal_authorsFile_missing_file.yml": ValidationResults{
 			ValidationWarning{
-				"legal.authorsFile",
-				"This key is DEPRECATED and will be removed in the future. It's safe to drop it",
-				42,
-				3,
+				Key:         "legal.authorsFile",
+				Description: "This key is DEPRECATED and will be removed in the future. It's safe to drop it",
+				Line:        42,
+				Column:      3,
+				EndLine:     42,
+				EndColumn:   41,
+				Code:        "PC-DEPRECATED-KEY",
 			},
 			ValidationError{
-				"legal.authorsFile",
-				"'" + cwd + "/testdata/v0/invalid/no_such_authors_file.txt' does not exist: no such file: " + cwd + "/testdata/v0/invalid/no_such_authors_file.txt",
-				42,
-				3,
+				Key:         "legal.authorsFile",
+				Description: "'" + cwd + "/testdata/v0/invalid/no_such_authors_file.txt' does not exist: no such file: " + cwd + "/testdata/v0/invalid/no_such_authors_file.txt",
+				Line:        42,
+				Column:      3,
+				EndLine:     42,
+				EndColumn:   41,
+				Code:        "PC-FILE-NOT-FOUND",
 			},
 		},
 
 		// maintenance
 		// maintenance.*
 		"maintenance_type_missing.yml": ValidationResults{
-			ValidationError{"maintenance.type", "type is a required field", 47, 3},
+			ValidationError{Key: "maintenance.type", Description: "type is a required field", Line: 47, Column: 3, EndLine: 47, EndColumn: 10, Code: "PC-INVALID-VALUE"},
 		},
 		"maintenance_type_invalid.yml": ValidationResults{
-			ValidationError{"maintenance.type", "type must be one of the following: \"internal\", \"contract\", \"community\" or \"none\"", 45, 3},
+			ValidationError{Key: "maintenance.type", Description: "type must be one of the following: \"internal\", \"contract\", \"community\" or \"none\"", Line: 45, Column: 3, EndLine: 45, EndColumn: 22, Code: "PC-INVALID-VALUE"},
 		},
 		"maintenance_contacts_missing_with_type_community.yml": ValidationResults{
-			ValidationError{"maintenance.contacts", "contacts is a required field when \"type\" is \"community\"", 44, 3},
+			ValidationError{Key: "maintenance.contacts", Description: "contacts is a required field when \"type\" is \"community\"", Line: 44, Column: 3, EndLine: 44, EndColumn: 6, Code: "PC-INVALID-VALUE"},
 		},
 		"maintenance_contacts_missing_with_type_internal.yml": ValidationResults{
-			ValidationError{"maintenance.contacts", "contacts is a required field when \"type\" is \"internal\"", 44, 3},
+			ValidationError{Key: "maintenance.contacts", Description: "contacts is a required field when \"type\" is \"internal\"", Line: 44, Column: 3, EndLine: 44, EndColumn: 6, Code: "PC-INVALID-VALUE"},
 		},
 		"maintenance_contacts_name_missing.yml": ValidationResults{
-			ValidationError{"maintenance.contacts[0].name", "name is a required field", 47, 7},
+			ValidationError{Key: "maintenance.contacts[0].name", Description: "name is a required field", Line: 47, Column: 7, EndLine: 47, EndColumn: 11, Code: "PC-INVALID-VALUE"},
 		},
 		"maintenance_contacts_email_invalid.yml": ValidationResults{
-			ValidationError{"maintenance.contacts[0].email", "email must be a valid email address", 49, 9},
+			ValidationError{Key: "maintenance.contacts[0].email", Description: "email must be a valid email address", Line: 49, Column: 9, EndLine: 49, EndColumn: 27, Code: "PC-INVALID-VALUE"},
 		},
 		"maintenance_contractors_missing_with_type_contract.yml": ValidationResults{
-			ValidationError{"maintenance.contractors", "contractors is a required field when \"type\" is \"contract\"", 44, 3},
+			ValidationError{Key: "maintenance.contractors", Description: "contractors is a required field when \"type\" is \"contract\"", Line: 44, Column: 3, EndLine: 44, EndColumn: 6, Code: "PC-INVALID-VALUE"},
 		},
 		"maintenance_contractors_invalid_type.yml": ValidationResults{
-			ValidationError{"maintenance.contractors", "wrong type for this field", 47, 1},
-			ValidationError{"maintenance.type", "type is a required field", 44, 3},
+			ValidationError{Key: "maintenance.contractors", Description: "wrong type for this field", Line: 47, Column: 16, EndLine: 47, EndColumn: 17, Code: "PC-WRONG-TYPE"},
 		},
 		"maintenance_contractors_name_missing.yml": ValidationResults{
-			ValidationError{"maintenance.contractors[0].name", "name is a required field", 47, 7},
+			ValidationError{Key: "maintenance.contractors[0].name", Description: "name is a required field", Line: 47, Column: 7, EndLine: 47, EndColumn: 11, Code: "PC-INVALID-VALUE"},
 		},
 		"maintenance_contractors_until_missing.yml": ValidationResults{
-			ValidationError{"maintenance.contractors[0].until", "until is a required field", 47, 7},
+			ValidationError{Key: "maintenance.contractors[0].until", Description: "until is a required field", Line: 47, Column: 7, EndLine: 47, EndColumn: 10, Code: "PC-INVALID-VALUE"},
 		},
 		"maintenance_contractors_until_invalid.yml": ValidationResults{
-			ValidationError{"maintenance.contractors[0].until", "until must be a date with format 'YYYY-MM-DD'", 49, 7},
+			ValidationError{Key: "maintenance.contractors[0].until", Description: "until must be a date with format 'YYYY-MM-DD'", Line: 49, Column: 7, EndLine: 49, EndColumn: 23, Code: "PC-INVALID-VALUE"},
 		},
 		"maintenance_contractors_email_invalid.yml": ValidationResults{
-			ValidationError{"maintenance.contractors[0].email", "email must be a valid email address", 50, 8},
+			ValidationError{Key: "maintenance.contractors[0].email", Description: "email must be a valid email address", Line: 50, Column: 8, EndLine: 50, EndColumn: 26, Code: "PC-INVALID-VALUE"},
 		},
 		"maintenance_contractors_website_invalid.yml": ValidationResults{
-			ValidationError{"maintenance.contractors[0].website", "website must be an HTTP URL", 52, 7},
+			ValidationError{Key: "maintenance.contractors[0].website", Description: "website must be an HTTP URL", Line: 52, Column: 7, EndLine: 52, EndColumn: 16, Code: "PC-INVALID-VALUE"},
 		},
 		"maintenance_contractors_when_type_is_community.yml": ValidationResults{
-			ValidationError{"maintenance.contractors", "contractors must not be present unless \"type\" is \"contract\"", 46, 3},
+			ValidationError{Key: "maintenance.contractors", Description: "contractors must not be present unless \"type\" is \"contract\"", Line: 46, Column: 3, EndLine: 46, EndColumn: 13, Code: "PC-INVALID-VALUE"},
 		},
 		"maintenance_contractors_when_type_is_internal.yml": ValidationResults{
-			ValidationError{"maintenance.contractors", "contractors must not be present unless \"type\" is \"contract\"", 46, 3},
+			ValidationError{Key: "maintenance.contractors", Description: "contractors must not be present unless \"type\" is \"contract\"", Line: 46, Column: 3, EndLine: 46, EndColumn: 13, Code: "PC-INVALID-VALUE"},
 		},
 		"maintenance_contractors_when_type_is_none.yml": ValidationResults{
-			ValidationError{"maintenance.contractors", "contractors must not be present unless \"type\" is \"contract\"", 46, 3},
+			ValidationError{Key: "maintenance.contractors", Description: "contractors must not be present unless \"type\" is \"contract\"", Line: 46, Column: 3, EndLine: 46, EndColumn: 13, Code: "PC-INVALID-VALUE"},
 		},
 
 		// localisation
 		"localisation_availableLanguages_missing.yml": ValidationResults{
-			ValidationError{"localisation.availableLanguages", "availableLanguages is a required field", 50, 3},
+			ValidationError{Key: "localisation.availableLanguages", Description: "availableLanguages is a required field", Line: 50, Column: 3, EndLine: 50, EndColumn: 19, Code: "PC-INVALID-VALUE"},
 		},
 		"localisation_availableLanguages_empty.yml": ValidationResults{
-			ValidationError{"localisation.availableLanguages", "availableLanguages must contain more than 0 items", 52, 3},
+			ValidationError{Key: "localisation.availableLanguages", Description: "availableLanguages must contain more than 0 items", Line: 52, Column: 3, EndLine: 52, EndColumn: 24, Code: "PC-INVALID-VALUE"},
 		},
 		"localisation_availableLanguages_invalid.yml": ValidationResults{
-			ValidationError{"localisation.availableLanguages[0]", "availableLanguages[0] must be a valid BCP 47 language", 53, 8},
+			ValidationError{Key: "localisation.availableLanguages[0]", Description: "availableLanguages[0] must be a valid BCP 47 language", Line: 53, Column: 8, EndLine: 53, EndColumn: 23, Code: "PC-INVALID-VALUE"},
 		},
 		"localisation_localisationReady_missing.yml": ValidationResults{
-			ValidationError{"localisation.localisationReady", "localisationReady is a required field", 52, 3},
+			ValidationError{Key: "localisation.localisationReady", Description: "localisationReady is a required field", Line: 52, Column: 3, EndLine: 52, EndColumn: 20, Code: "PC-INVALID-VALUE"},
 		},
 
 		// dependsOn
 		"dependsOn_open_name_wrong_type.yml": ValidationResults{
-			ValidationError{"dependsOn.open[0]", "wrong type for this field", 56, 1},
+			ValidationError{Key: "dependsOn.open[0]", Description: "wrong type for this field", Line: 56, Column: 13, EndLine: 56, EndColumn: 14, Code: "PC-WRONG-TYPE"},
 		},
 		"dependsOn_open_versionMin_wrong_type.yml": ValidationResults{
-			ValidationError{"dependsOn.open[0].versionMin", "wrong type for this field", 57, 1},
+			ValidationError{Key: "dependsOn.open[0].versionMin", Description: "wrong type for this field", Line: 57, Column: 19, EndLine: 57, EndColumn: 20, Code: "PC-WRONG-TYPE"},
 		},
 		"dependsOn_open_versionMax_wrong_type.yml": ValidationResults{
-			ValidationError{"dependsOn.open[0].versionMax", "wrong type for this field", 57, 1},
+			ValidationError{Key: "dependsOn.open[0].versionMax", Description: "wrong type for this field", Line: 57, Column: 19, EndLine: 57, EndColumn: 20, Code: "PC-WRONG-TYPE"},
 		},
 		"dependsOn_open_version_wrong_type.yml": ValidationResults{
-			ValidationError{"dependsOn.open[0].version", "wrong type for this field", 57, 1},
+			ValidationError{Key: "dependsOn.open[0].version", Description: "wrong type for this field", Line: 57, Column: 16, EndLine: 57, EndColumn: 17, Code: "PC-WRONG-TYPE"},
 		},
 		"dependsOn_open_optional_wrong_type.yml": ValidationResults{
-			ValidationError{"dependsOn.open[0].optional", "wrong type for this field", 57, 1},
+			ValidationError{Key: "dependsOn.open[0].optional", Description: "wrong type for this field", Line: 57, Column: 17, EndLine: 57, EndColumn: 22, Code: "PC-WRONG-TYPE"},
 		},
 
 		// it.*
 		"it_countryExtensionVersion_invalid.yml": ValidationResults{
-			ValidationError{"IT.countryExtensionVersion", "countryExtensionVersion must be one of the following: \"0.2\" or \"1.0\"", 12, 3},
+			ValidationError{Key: "IT.countryExtensionVersion", Description: "countryExtensionVersion must be one of the following: \"0.2\" or \"1.0\"", Line: 12, Column: 3, EndLine: 12, EndColumn: 32, Code: "PC-INVALID-VALUE"},
 		},
 		"it_riuso_codiceIPA_invalid.yml": ValidationResults{
-			ValidationError{"IT.riuso.codiceIPA", "codiceIPA must be a valid Italian Public Administration Code (iPA) (see https://github.com/publiccodeyml/italian-organizations-ipa-vocabulary)", 55, 5},
+			ValidationError{Key: "IT.riuso.codiceIPA", Description: "codiceIPA must be a valid Italian Public Administration Code (iPA) (see https://github.com/publiccodeyml/italian-organizations-ipa-vocabulary)", Line: 55, Column: 5, EndLine: 55, EndColumn: 31, Code: "PC-INVALID-VALUE"},
 		},
 		"it_IT_duplicated.yml": ValidationResults{
-			ValidationWarning{"it", "Lowercase country codes are DEPRECATED and will be removed in the future. Use 'IT' instead", 116, 1},
-			ValidationError{"it", "'IT' key already present. Remove this key", 116, 1},
+			ValidationWarning{Key: "it", Description: "Lowercase country codes are DEPRECATED and will be removed in the future. Use 'IT' instead", Line: 116, Column: 1, EndLine: 116, EndColumn: 2, Code: "PC-DEPRECATED-KEY"},
+			ValidationError{Key: "it", Description: "'IT' key already present. Remove this key", Line: 116, Column: 1, EndLine: 116, EndColumn: 2, Code: "PC-DUPLICATE-KEY"},
 		},
 		"it_wrong_case.yml": ValidationResults{
-			ValidationError{"It", "unknown field \"It\"", 107, 1},
+			ValidationError{Key: "It", Description: "unknown field \"It\", did you mean 'IT'?", Line: 107, Column: 1, EndLine: 107, EndColumn: 2, Code: "PC-UNKNOWN-FIELD"},
 		},
 		"description_en_gb_invalid_bcp47.yml": ValidationResults{
-			ValidationError{"description", "description must be a valid BCP 47 language", 18, 1},
+			ValidationError{Key: "description", Description: "description must be a valid BCP 47 language", Line: 18, Column: 1, EndLine: 18, EndColumn: 11, Code: "PC-INVALID-VALUE"},
 		},
 		"localisation_availableLanguages_invalid_bcp47.yml": ValidationResults{
-			ValidationError{"localisation.availableLanguages[0]", "availableLanguages[0] must be a valid BCP 47 language", 54, 8},
+			ValidationError{Key: "localisation.availableLanguages[0]", Description: "availableLanguages[0] must be a valid BCP 47 language", Line: 54, Column: 8, EndLine: 54, EndColumn: 12, Code: "PC-INVALID-VALUE"},
 		},
 
 		// misc
-		"file_encoding.yml": ValidationResults{ValidationError{"", "Invalid UTF-8", 0, 0}},
-		"invalid_yaml.yml":  ValidationResults{ValidationError{"", "value is not allowed in this context. map key-value is pre-defined", 38, 1}},
+		"file_encoding.yml": ValidationResults{ValidationError{Description: "Invalid UTF-8", Code: "PC-INVALID-YAML"}},
+		"invalid_yaml.yml":  ValidationResults{ValidationError{Description: "value is not allowed in this context. map key-value is pre-defined", Line: 38, Column: 6, EndLine: 38, EndColumn: 13, Code: "PC-INVALID-YAML"}},
 		"mostly_empty.yml": ValidationResults{
-			ValidationError{"name", "name is a required field", 1, 1},
-			ValidationError{"url", "url is a required field", 1, 1},
-			ValidationError{"platforms", "platforms must contain more than 0 items", 1, 1},
-			ValidationError{"developmentStatus", "developmentStatus is a required field", 1, 1},
-			ValidationError{"softwareType", "softwareType is a required field", 1, 1},
-			ValidationError{"description[en-US].shortDescription", "shortDescription is a required field", 3, 10},
-			ValidationError{"description[en-US].longDescription", "longDescription is a required field", 3, 10},
-			ValidationError{"description[en-US].features", "features must contain more than 0 items", 3, 10},
-			ValidationError{"legal.license", "license is a required field", 5, 8},
-			ValidationError{"maintenance.type", "type is a required field", 6, 14},
-			ValidationError{"localisation.localisationReady", "localisationReady is a required field", 4, 15},
-			ValidationError{"localisation.availableLanguages", "availableLanguages is a required field", 4, 15},
+			ValidationError{Key: "name", Description: "name is a required field", Line: 1, Column: 1, EndLine: 1, EndColumn: 20, Code: "PC-INVALID-VALUE"},
+			ValidationError{Key: "url", Description: "url is a required field", Line: 1, Column: 1, EndLine: 1, EndColumn: 20, Code: "PC-INVALID-VALUE"},
+			ValidationError{Key: "platforms", Description: "platforms must contain more than 0 items", Line: 1, Column: 1, EndLine: 1, EndColumn: 20, Code: "PC-INVALID-VALUE"},
+			ValidationError{Key: "developmentStatus", Description: "developmentStatus is a required field", Line: 1, Column: 1, EndLine: 1, EndColumn: 20, Code: "PC-INVALID-VALUE"},
+			ValidationError{Key: "softwareType", Description: "softwareType is a required field", Line: 1, Column: 1, EndLine: 1, EndColumn: 20, Code: "PC-INVALID-VALUE"},
+			ValidationError{Key: "description[en-US].shortDescription", Description: "shortDescription is a required field", Line: 3, Column: 10, EndLine: 3, EndColumn: 10, Code: "PC-INVALID-VALUE"},
+			ValidationError{Key: "description[en-US].longDescription", Description: "longDescription is a required field", Line: 3, Column: 10, EndLine: 3, EndColumn: 10, Code: "PC-INVALID-VALUE"},
+			ValidationError{Key: "description[en-US].features", Description: "features must contain more than 0 items", Line: 3, Column: 10, EndLine: 3, EndColumn: 10, Code: "PC-INVALID-VALUE"},
+			ValidationError{Key: "legal.license", Description: "license is a required field", Line: 5, Column: 8, EndLine: 5, EndColumn: 8, Code: "PC-INVALID-VALUE"},
+			ValidationError{Key: "maintenance.type", Description: "type is a required field", Line: 6, Column: 14, EndLine: 6, EndColumn: 14, Code: "PC-INVALID-VALUE"},
+			ValidationError{Key: "localisation.localisationReady", Description: "localisationReady is a required field", Line: 4, Column: 15, EndLine: 4, EndColumn: 15, Code: "PC-INVALID-VALUE"},
+			ValidationError{Key: "localisation.availableLanguages", Description: "availableLanguages is a required field", Line: 4, Column: 15, EndLine: 4, EndColumn: 15, Code: "PC-INVALID-VALUE"},
 		},
 		"unknown_field.yml": ValidationResults{
-			ValidationError{"foobar", "unknown field \"foobar\"", 10, 1},
+			ValidationError{Key: "foobar", Description: "unknown field \"foobar\"", Line: 10, Column: 1, EndLine: 10, EndColumn: 6, Code: "PC-UNKNOWN-FIELD"},
 		},
 	}
 
@@ -606,37 +618,40 @@ func TestValidTestcasesV0(t *testing.T) {
 func TestValidWithWarningsTestcasesV0(t *testing.T) {
 	expected := map[string]error{
 		"unicode_grapheme_clusters.yml": ValidationResults{
-			ValidationWarning{"description.en.genericName", "This key is DEPRECATED and will be removed in the future. It's safe to drop it", 23, 5},
+			ValidationWarning{Key: "description.en.genericName", Description: "This key is DEPRECATED and will be removed in the future. It's safe to drop it", Line: 23, Column: 5, EndLine: 23, EndColumn: 31, Code: "PC-DEPRECATED-KEY"},
 		},
 		"valid.minimal.v0.2.yml": ValidationResults{
-			ValidationWarning{"publiccodeYmlVersion", "v0.2 is not the latest version, use '0'. Parsing this file as v0.7.", 1, 1},
+			ValidationWarning{Key: "publiccodeYmlVersion", Description: "v0.2 is not the latest version, use '0'. Parsing this file as v0.7.", Line: 1, Column: 1, EndLine: 1, EndColumn: 27, Code: "PC-OLD-VERSION"},
 		},
 		"valid.minimal.v0.3.yml": ValidationResults{
-			ValidationWarning{"publiccodeYmlVersion", "v0.3 is not the latest version, use '0'. Parsing this file as v0.7.", 1, 1},
+			ValidationWarning{Key: "publiccodeYmlVersion", Description: "v0.3 is not the latest version, use '0'. Parsing this file as v0.7.", Line: 1, Column: 1, EndLine: 1, EndColumn: 27, Code: "PC-OLD-VERSION"},
 		},
 		"valid.minimal.v0.4.yml": ValidationResults{
-			ValidationWarning{"publiccodeYmlVersion", "v0.4 is not the latest version, use '0'. Parsing this file as v0.7.", 1, 1},
+			ValidationWarning{Key: "publiccodeYmlVersion", Description: "v0.4 is not the latest version, use '0'. Parsing this file as v0.7.", Line: 1, Column: 1, EndLine: 1, EndColumn: 27, Code: "PC-OLD-VERSION"},
 		},
 		"valid.mime_types.yml": ValidationResults{
-			ValidationWarning{"inputTypes", "This key is DEPRECATED and will be removed in the future. It's safe to drop it", 48, 1},
-			ValidationWarning{"outputTypes", "This key is DEPRECATED and will be removed in the future. It's safe to drop it", 50, 1},
+			ValidationWarning{Key: "inputTypes", Description: "This key is DEPRECATED and will be removed in the future. It's safe to drop it", Line: 48, Column: 1, EndLine: 48, EndColumn: 10, Code: "PC-DEPRECATED-KEY"},
+			ValidationWarning{Key: "outputTypes", Description: "This key is DEPRECATED and will be removed in the future. It's safe to drop it", Line: 50, Column: 1, EndLine: 50, EndColumn: 11, Code: "PC-DEPRECATED-KEY"},
 		},
 		"valid_with_it_conforme.yml": ValidationResults{
-			ValidationWarning{"IT.conforme", "This key is DEPRECATED and will be removed in the future. It's safe to drop it", 119, 3},
+			ValidationWarning{Key: "IT.conforme", Description: "This key is DEPRECATED and will be removed in the future. It's safe to drop it", Line: 119, Column: 3, EndLine: 119, EndColumn: 10, Code: "PC-DEPRECATED-KEY"},
 		},
 		"valid_with_country_specific_section_downcase.yml": ValidationResults{
-			ValidationWarning{"it", "Lowercase country codes are DEPRECATED and will be removed in the future. Use 'IT' instead", 107, 1},
+			ValidationWarning{Key: "it", Description: "Lowercase country codes are DEPRECATED and will be removed in the future. Use 'IT' instead", Line: 107, Column: 1, EndLine: 107, EndColumn: 2, Code: "PC-DEPRECATED-KEY", Fix: &Fix{Edits: []Edit{{Op: EditRename, Path: "$.it", Value: "IT"}}}},
 		},
 		"valid_with_lowercase_countries.yml": ValidationResults{
-			ValidationWarning{"intendedAudience.countries[0]", "Lowercase country codes are DEPRECATED. Use uppercase instead ('IT')", 31, 7},
-			ValidationWarning{"intendedAudience.countries[1]", "Lowercase country codes are DEPRECATED. Use uppercase instead ('DE')", 32, 7},
-			ValidationWarning{"intendedAudience.unsupportedCountries[0]", "Lowercase country codes are DEPRECATED. Use uppercase instead ('US')", 34, 7},
+			ValidationWarning{Key: "intendedAudience.countries[0]", Description: "Lowercase country codes are DEPRECATED. Use uppercase instead ('IT')", Line: 31, Column: 7, EndLine: 31, EndColumn: 8, Code: "PC-LOWERCASE-COUNTRY-CODE", Fix: &Fix{Edits: []Edit{{Op: EditReplace, Path: "$.intendedAudience.countries[0]", Value: "IT"}}}},
+			ValidationWarning{Key: "intendedAudience.countries[1]", Description: "Lowercase country codes are DEPRECATED. Use uppercase instead ('DE')", Line: 32, Column: 7, EndLine: 32, EndColumn: 8, Code: "PC-LOWERCASE-COUNTRY-CODE", Fix: &Fix{Edits: []Edit{{Op: EditReplace, Path: "$.intendedAudience.countries[1]", Value: "DE"}}}},
+			ValidationWarning{Key: "intendedAudience.unsupportedCountries[0]", Description: "Lowercase country codes are DEPRECATED. Use uppercase instead ('US')", Line: 34, Column: 7, EndLine: 34, EndColumn: 8, Code: "PC-LOWERCASE-COUNTRY-CODE", Fix: &Fix{Edits: []Edit{{Op: EditReplace, Path: "$.intendedAudience.unsupportedCountries[0]", Value: "US"}}}},
 		},
 		"valid_with_legal_repoOwner.yml": ValidationResults{
-			ValidationWarning{"legal.repoOwner", "This key is DEPRECATED and will be removed in the future. Use 'organisation.name' instead", 70, 3},
+			ValidationWarning{Key: "legal.repoOwner", Description: "This key is DEPRECATED and will be removed in the future. Use 'organisation.name' instead", Line: 70, Column: 3, EndLine: 70, EndColumn: 28, Code: "PC-DEPRECATED-KEY"},
 		},
 		"valid_with_IT_riuso_codiceIPA.yml": ValidationResults{
-			ValidationWarning{"IT.riuso.codiceIPA", "This key is DEPRECATED and will be removed in the future. Use 'organisation.uri' and set it to 'urn:x-italian-pa:pcm' instead", 119, 5},
+			ValidationWarning{Key: "IT.riuso.codiceIPA", Description: "This key is DEPRECATED and will be removed in the future. Use 'organisation.uri' and set it to 'urn:x-italian-pa:pcm' instead", Line: 119, Column: 5, EndLine: 119, EndColumn: 18, Code: "PC-DEPRECATED-KEY", Fix: &Fix{Edits: []Edit{
+				{Op: EditRemove, Path: "$.IT.riuso.codiceIPA"},
+				{Op: EditSet, Path: "$.organisation.uri", Value: "urn:x-italian-pa:pcm"},
+			}}},
 		},
 	}
 
@@ -663,9 +678,9 @@ func TestValidWithWarningsTestcasesV0(t *testing.T) {
 func TestDecodeValueErrorsRemote(t *testing.T) {
 	testRemoteFiles := []testType{
 		{"https://raw.githubusercontent.com/italia/publiccode-parser-go/refs/heads/main/testdata/v0/valid_with_warnings/valid_with_lowercase_countries.yml", ValidationResults{
-			ValidationWarning{"intendedAudience.countries[0]", "Lowercase country codes are DEPRECATED. Use uppercase instead ('IT')", 31, 7},
-			ValidationWarning{"intendedAudience.countries[1]", "Lowercase country codes are DEPRECATED. Use uppercase instead ('DE')", 32, 7},
-			ValidationWarning{"intendedAudience.unsupportedCountries[0]", "Lowercase country codes are DEPRECATED. Use uppercase instead ('US')", 34, 7},
+			ValidationWarning{Key: "intendedAudience.countries[0]", Description: "Lowercase country codes are DEPRECATED. Use uppercase instead ('IT')", Line: 31, Column: 7, EndLine: 31, EndColumn: 8, Code: "PC-LOWERCASE-COUNTRY-CODE", Fix: &Fix{Edits: []Edit{{Op: EditReplace, Path: "$.intendedAudience.countries[0]", Value: "IT"}}}},
+			ValidationWarning{Key: "intendedAudience.countries[1]", Description: "Lowercase country codes are DEPRECATED. Use uppercase instead ('DE')", Line: 32, Column: 7, EndLine: 32, EndColumn: 8, Code: "PC-LOWERCASE-COUNTRY-CODE", Fix: &Fix{Edits: []Edit{{Op: EditReplace, Path: "$.intendedAudience.countries[1]", Value: "DE"}}}},
+			ValidationWarning{Key: "intendedAudience.unsupportedCountries[0]", Description: "Lowercase country codes are DEPRECATED. Use uppercase instead ('US')", Line: 34, Column: 7, EndLine: 34, EndColumn: 8, Code: "PC-LOWERCASE-COUNTRY-CODE", Fix: &Fix{Edits: []Edit{{Op: EditReplace, Path: "$.intendedAudience.unsupportedCountries[0]", Value: "US"}}}},
 		}},
 	}
 
@@ -692,19 +707,19 @@ func TestRelativePathsOrURLs(t *testing.T) {
 		// Remote publiccode.yml and relative path in screenshots:
 		// should look for the screenshot remotely relative to this URL
 		{"https://raw.githubusercontent.com/italia/publiccode-parser-go/refs/heads/main/testdata/v0/invalid/description_en_screenshots_missing_file.yml", ValidationResults{
-			ValidationError{"description.en.screenshots[0]", "'no_such_file.png' is not an image: HTTP GET failed for https://raw.githubusercontent.com/italia/publiccode-parser-go/refs/heads/main/testdata/v0/invalid/no_such_file.png: not found", 42, 9},
+			ValidationError{Key: "description.en.screenshots[0]", Description: "'no_such_file.png' is not an image: HTTP GET failed for https://raw.githubusercontent.com/italia/publiccode-parser-go/refs/heads/main/testdata/v0/invalid/no_such_file.png: not found", Line: 42, Column: 9, EndLine: 42, EndColumn: 24, Code: "PC-INVALID-IMAGE"},
 		}},
 
 		// Local publiccode.yml and relative path in screenshot:
 		// should look for the logo relative to this path in the filesystem
 		{"testdata/v0/invalid/description_en_screenshots_missing_file.yml", ValidationResults{
-			ValidationError{"description.en.screenshots[0]", "'no_such_file.png' is not an image: no such file: " + cwd + "/testdata/v0/invalid/no_such_file.png", 42, 9},
+			ValidationError{Key: "description.en.screenshots[0]", Description: "'no_such_file.png' is not an image: no such file: " + cwd + "/testdata/v0/invalid/no_such_file.png", Line: 42, Column: 9, EndLine: 42, EndColumn: 24, Code: "PC-INVALID-IMAGE"},
 		}},
 
 		// Remote publiccode.yml and URL in logo:
 		// should look for the logo remotely
 		{"https://raw.githubusercontent.com/italia/publiccode-parser-go/refs/heads/main/testdata/v0/invalid/logo_missing_url.yml", ValidationResults{
-			ValidationError{"logo", "HTTP GET failed for https://google.com/no_such_file.png: not found", 18, 1},
+			ValidationError{Key: "logo", Description: "HTTP GET failed for https://google.com/no_such_file.png: not found", Line: 18, Column: 1, EndLine: 18, EndColumn: 43, Code: "PC-INVALID-LOGO"},
 		}},
 
 		// Local publiccode.yml and URL in logo:
@@ -712,7 +727,7 @@ func TestRelativePathsOrURLs(t *testing.T) {
 		//
 		// (already tested in TestInvalidTestcasesV0)
 		// "testdata/v0/invalid/logo_missing_url.yml", ValidationResults{
-		//	ValidationError{"logo", "HTTP GET failed for https://google.com/no_such_file.png: not found", 18, 1},
+		//	ValidationError{"logo", "HTTP GET failed for https://google.com/no_such_file.png: not found", 18, 1, 18, 43, "PC-INVALID-LOGO", nil},
 		//}},
 	}
 
@@ -767,7 +782,7 @@ func TestRelativePathsOrURLsNoNetwork(t *testing.T) {
 		// should look for the logo relative to this path in the filesystem.
 		// DisableNetwork doesn't affect this so the check *IS* performed.
 		{"testdata/v0/invalid/description_en_screenshots_missing_file.yml", ValidationResults{
-			ValidationError{"description.en.screenshots[0]", "'no_such_file.png' is not an image: no such file: " + cwd + "/testdata/v0/invalid/no_such_file.png", 42, 9},
+			ValidationError{Key: "description.en.screenshots[0]", Description: "'no_such_file.png' is not an image: no such file: " + cwd + "/testdata/v0/invalid/no_such_file.png", Line: 42, Column: 9, EndLine: 42, EndColumn: 24, Code: "PC-INVALID-IMAGE"},
 		}},
 	}
 
@@ -790,7 +805,7 @@ func TestRelativePathsOrURLsNoNetwork(t *testing.T) {
 func TestUrlMissingWithoutPath(t *testing.T) {
 	expected := map[string]error{
 		"url_missing.yml": ValidationResults{
-			ValidationError{"url", "url is a required field", 1, 1},
+			ValidationError{Key: "url", Description: "url is a required field", Line: 1, Column: 1, EndLine: 1, EndColumn: 20, Code: "PC-INVALID-VALUE"},
 		},
 	}
 
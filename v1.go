@@ -45,21 +45,11 @@ type PublicCodeV1 struct {
 
 	Description map[string]DescV1 `json:"description" validate:"gt=0,bcp47_keys,dive" yaml:"description"`
 
-	Legal struct {
-		License            string  `json:"license"                      validate:"required,is_spdx_expression" yaml:"license"`
-		MainCopyrightOwner *string `json:"mainCopyrightOwner,omitempty" yaml:"mainCopyrightOwner,omitempty"`
-	} `yaml:"legal" json:"legal"`
-
-	Maintenance struct {
-		Type        string          `json:"type"                  validate:"required,oneof=internal contract community none"                        yaml:"type"`
-		Contractors *[]ContractorV1 `json:"contractors,omitempty" validate:"required_if=Type contract,excluded_unless=Type contract,omitempty,dive" yaml:"contractors,omitempty"`
-		Contacts    *[]ContactV1    `json:"contacts,omitempty"    validate:"required_if=Type community,required_if=Type internal,omitempty,dive"    yaml:"contacts,omitempty"`
-	} `yaml:"maintenance" json:"maintenance"`
-
-	Localisation struct {
-		LocalisationReady  *bool    `json:"localisationReady"  validate:"required"                                     yaml:"localisationReady"`
-		AvailableLanguages []string `json:"availableLanguages" validate:"required,gt=0,dive,bcp47_strict_language_tag" yaml:"availableLanguages"`
-	} `yaml:"localisation" json:"localisation"`
+	Legal LegalV1 `yaml:"legal" json:"legal"`
+
+	Maintenance MaintenanceV1 `yaml:"maintenance" json:"maintenance"`
+
+	Localisation LocalisationV1 `yaml:"localisation" json:"localisation"`
 
 	DependsOn *struct {
 		Open        *[]DependencyV1 `json:"open,omitempty"        validate:"omitempty,dive" yaml:"open,omitempty"`
@@ -81,6 +71,25 @@ type DescV1 struct {
 	Awards           []string  `json:"awards,omitempty"           yaml:"awards,omitempty"`
 }
 
+// LegalV1 is the legal information of the software.
+type LegalV1 struct {
+	License            string  `json:"license"                      validate:"required,is_spdx_expression" yaml:"license"`
+	MainCopyrightOwner *string `json:"mainCopyrightOwner,omitempty" yaml:"mainCopyrightOwner,omitempty"`
+}
+
+// MaintenanceV1 describes how the software is maintained.
+type MaintenanceV1 struct {
+	Type        string          `json:"type"                  validate:"required,oneof=internal contract community none"                        yaml:"type"`
+	Contractors *[]ContractorV1 `json:"contractors,omitempty" validate:"required_if=Type contract,excluded_unless=Type contract,omitempty,dive" yaml:"contractors,omitempty"`
+	Contacts    *[]ContactV1    `json:"contacts,omitempty"    validate:"required_if=Type community,required_if=Type internal,omitempty,dive"    yaml:"contacts,omitempty"`
+}
+
+// LocalisationV1 describes the localisation of the software.
+type LocalisationV1 struct {
+	LocalisationReady  *bool    `json:"localisationReady"  validate:"required"                                     yaml:"localisationReady"`
+	AvailableLanguages []string `json:"availableLanguages" validate:"required,gt=0,dive,bcp47_strict_language_tag" yaml:"availableLanguages"`
+}
+
 // ContractorV1 is an entity or entities, if any, that are currently contracted for maintaining the software.
 type ContractorV1 struct {
 	Name    string  `json:"name"              validate:"required"             yaml:"name"`
@@ -13,10 +13,9 @@ func TestInvalidTestcasesV1(t *testing.T) {
 
 	expected := ValidationResults{
 		ValidationError{
-			"publiccodeYmlVersion",
-			"unsupported version: '1'. Supported versions: 0, 0.2, 0.2.0, 0.2.1, 0.2.2, 0.3, 0.3.0, 0.4, 0.4.0, 0.5.0, 0.5, 0.7.0, 0.7",
-			0,
-			0,
+			Key:         "publiccodeYmlVersion",
+			Description: "unsupported version: '1'. Supported versions: 0, 0.2, 0.2.0, 0.2.1, 0.2.2, 0.3, 0.3.0, 0.4, 0.4.0, 0.5.0, 0.5, 0.7.0, 0.7",
+			Code:        "PC-UNSUPPORTED-VERSION",
 		},
 	}
 
@@ -31,3 +30,35 @@ func TestInvalidTestcasesV1(t *testing.T) {
 		}
 	}
 }
+
+func TestDraftVersions(t *testing.T) {
+	p, err := NewParser(ParserConfig{DisableExternalChecks: true, EnableDraftVersions: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pc, err := p.Parse("testdata/v1/valid/valid.yml")
+
+	expected := ValidationResults{
+		ValidationWarning{
+			Key:         "publiccodeYmlVersion",
+			Description: "v1 is a draft version, its schema is experimental and might change.",
+			Line:        1,
+			Column:      1,
+			EndLine:     1,
+			EndColumn:   25,
+			Code:        "PC-DRAFT-VERSION",
+		},
+	}
+	checkParseErrors(t, err, testType{"testdata/v1/valid/valid.yml", expected})
+
+	if _, ok := pc.(*PublicCodeV1); !ok {
+		t.Errorf("expected *PublicCodeV1, got %T", pc)
+	}
+
+	// Draft files are validated with the v1 rules.
+	_, err = p.Parse("testdata/v1/invalid/name_missing.yml")
+	if !hasErrors(err) {
+		t.Errorf("expected errors for an invalid draft file, got %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package publiccode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Validate validates publiccode built in code (eg. from form input, see
+// NewLegalV0 and the other constructors), a PublicCodeV0 or PublicCodeV1 or
+// a pointer to one, with the same checks Parse runs after decoding the YAML:
+// the validate tags and the checks on the fields, including the external ones
+// as set in ParserConfig.
+//
+// The ValidationResults have keys but no positions, and are nil if
+// publiccode is valid. The Rules in ParserConfig aren't run, as they need
+// the YAML of the file.
+func (p *Parser) Validate(publiccode PublicCode) ValidationResults {
+	return p.ValidateContext(context.Background(), publiccode)
+}
+
+// ValidateContext is like Validate, with the context semantics of
+// ParseStreamContext.
+func (p *Parser) ValidateContext(ctx context.Context, publiccode PublicCode) ValidationResults {
+	ctx, cancel := p.withParseTimeout(ctx)
+	defer cancel()
+
+	// The checks take pointers, work on a copy not to touch publiccode.
+	var validateFields validateFn
+
+	switch v := publiccode.(type) {
+	case PublicCodeV0:
+		publiccode, validateFields = &v, validateFieldsV0
+	case *PublicCodeV0:
+		if v != nil {
+			v0 := *v
+			publiccode, validateFields = &v0, validateFieldsV0
+		}
+	case PublicCodeV1:
+		publiccode, validateFields = &v, validateFieldsV1
+	case *PublicCodeV1:
+		if v != nil {
+			v1 := *v
+			publiccode, validateFields = &v1, validateFieldsV1
+		}
+	}
+
+	// Unknown types and nil pointers.
+	if validateFields == nil {
+		return ValidationResults{withCheck(CheckInternal, newValidationError(
+			"", p.message("cant_validate", fmt.Sprintf("%T", publiccode)),
+		))}
+	}
+
+	// Parse sets IT to the deprecated it section, it's not a duplicate.
+	if v0, ok := publiccode.(*PublicCodeV0); ok && v0.It != nil && v0.IT == v0.It {
+		v0.IT = nil
+	}
+
+	var ve ValidationResults
+
+	for _, valErr := range validateStruct(p.trans, publiccode) {
+		ve = append(ve, valErr)
+	}
+
+	baseURL, err := p.resolveBaseURL(ctx, publiccode, nil)
+	if err != nil {
+		return p.results(append(ve, err))
+	}
+
+	if err := validateFields(ctx, publiccode, p, !p.disableNetwork, baseURL); err != nil {
+		var vr ValidationResults
+		if !errors.As(err, &vr) {
+			vr = ValidationResults{withCheck(CheckInternal, newValidationError("", err.Error()))}
+		}
+
+		// External checks run concurrently and over map keys, sort the
+		// results so the output is the same on every run.
+		slices.SortStableFunc(vr, compareByKeyAndLine)
+
+		ve = append(ve, vr...)
+	}
+
+	return p.results(ve)
+}
+
+// results is applySeverity, returning nil if there are no results left.
+func (p *Parser) results(vr ValidationResults) ValidationResults {
+	if vr = p.applySeverity(vr); len(vr) == 0 {
+		return nil
+	}
+
+	return vr
+}
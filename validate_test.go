@@ -0,0 +1,159 @@
+package publiccode
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func newValidatePublicCodeV0() PublicCodeV0 {
+	url, _ := toURL("https://github.com/italia/developers.italia.it.git")
+	releaseDate := "2017-04-15"
+
+	return PublicCodeV0{
+		PubliccodeYamlVersion: "0",
+		Name:                  "Medusa",
+		URL:                   (*URL)(url),
+		ReleaseDate:           &releaseDate,
+		Platforms:             []string{"web"},
+		DevelopmentStatus:     "stable",
+		SoftwareType:          "standalone/web",
+		Description: map[string]DescV0{
+			"en": {
+				ShortDescription: "A rather short description which is probably useless",
+				LongDescription: "Very long description of this software, also split on multiple rows. " +
+					"You should note what the software is and why one should need it. " +
+					"This is 158 characters.",
+				Features: &[]string{"Just one feature"},
+			},
+		},
+		Legal:        NewLegalV0("AGPL-3.0-or-later").WithMainCopyrightOwner("City of Amsterdam"),
+		Maintenance:  NewMaintenanceV0("community").WithContacts(ContactV0{Name: "Francesco Rossi"}),
+		Localisation: NewLocalisationV0(true, "en"),
+	}
+}
+
+func TestValidate(t *testing.T) {
+	p, err := NewParser(ParserConfig{DisableExternalChecks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v0 := newValidatePublicCodeV0()
+	if vr := p.Validate(v0); vr != nil {
+		t.Errorf("unexpected results: %v", vr)
+	}
+
+	v0.Maintenance = NewMaintenanceV0("community")
+	v0.Description["en"] = DescV0{ShortDescription: "Short", Features: &[]string{}}
+
+	expected := ValidationResults{
+		ValidationError{
+			Key:         "description.en.longDescription",
+			Description: "longDescription is a required field",
+			Code:        "PC-INVALID-VALUE",
+		},
+		ValidationError{
+			Key:         "description.en.features",
+			Description: "features must contain more than 0 items",
+			Code:        "PC-INVALID-VALUE",
+		},
+		ValidationError{
+			Key:         "maintenance.contacts",
+			Description: "contacts is a required field when \"type\" is \"community\"",
+			Code:        "PC-INVALID-VALUE",
+		},
+	}
+
+	if vr := p.Validate(&v0); !reflect.DeepEqual(vr, expected) {
+		t.Errorf("wrong results:\n%#v\n- instead of:\n%#v", vr, expected)
+	}
+}
+
+// TestValidateParsed checks Validate gives the results of Parse, without the
+// positions.
+func TestValidateParsed(t *testing.T) {
+	p, err := NewParser(ParserConfig{DisableExternalChecks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, _ := filepath.Glob("testdata/v0/valid_with_warnings/*.yml")
+
+	for _, file := range files {
+		// Parse sets organisation from IT.riuso.codiceIPA.
+		if filepath.Base(file) == "valid_with_IT_riuso_codiceIPA.yml" {
+			continue
+		}
+
+		t.Run(file, func(t *testing.T) {
+			pc, err := p.Parse(file)
+
+			var parsed ValidationResults
+			if !errors.As(err, &parsed) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var expected ValidationResults
+
+			for _, res := range parsed {
+				switch e := res.(type) { //nolint:errorlint // the results are never wrapped
+				case ValidationError:
+					e.Line, e.Column, e.EndLine, e.EndColumn = 0, 0, 0, 0
+					expected = append(expected, e)
+				case ValidationWarning:
+					// The version is only checked by Parse.
+					if e.Code != CheckOldVersion.Code() {
+						e.Line, e.Column, e.EndLine, e.EndColumn = 0, 0, 0, 0
+						expected = append(expected, e)
+					}
+				}
+			}
+
+			if vr := p.Validate(pc); !reflect.DeepEqual(vr, expected) {
+				t.Errorf("wrong results:\n%v\n- instead of:\n%v", vr, expected)
+			}
+		})
+	}
+}
+
+func TestValidateDoesntModify(t *testing.T) {
+	p, err := NewParser(ParserConfig{DisableExternalChecks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v0 := newValidatePublicCodeV0()
+	before := newValidatePublicCodeV0()
+
+	_ = p.Validate(&v0)
+
+	if !reflect.DeepEqual(v0, before) {
+		t.Errorf("Validate modified the PublicCode:\n%v", v0)
+	}
+
+	for _, pc := range []PublicCode{nil, (*PublicCodeV0)(nil), (*PublicCodeV1)(nil)} {
+		if vr := p.Validate(pc); len(vr) != 1 {
+			t.Errorf("expected an error validating %#v, got %v", pc, vr)
+		}
+	}
+}
+
+func TestBuilders(t *testing.T) {
+	m := NewMaintenanceV1("contract").WithContractors(ContractorV1{Name: "Fornitore", Until: "2019-01-01"})
+	more := m.WithContractors(ContractorV1{Name: "Altro fornitore", Until: "2020-01-01"})
+
+	if len(*m.Contractors) != 1 || len(*more.Contractors) != 2 {
+		t.Errorf("With modified the original: %v, %v", *m.Contractors, *more.Contractors)
+	}
+
+	l := NewLocalisationV1(false, "it", "en")
+	if *l.LocalisationReady || !reflect.DeepEqual(l.AvailableLanguages, []string{"it", "en"}) {
+		t.Errorf("wrong localisation: %v", l)
+	}
+
+	if legal := NewLegalV1("MIT").WithMainCopyrightOwner("Comune di Roma"); *legal.MainCopyrightOwner != "Comune di Roma" {
+		t.Errorf("wrong legal: %v", legal)
+	}
+}
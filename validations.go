@@ -1,13 +1,19 @@
 package publiccode
 
 import (
+	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"image"
 	"image/png"
+	"io"
+	"io/fs"
 	"math/rand"
+	"net/http"
 	"net/url"
 	"os"
 	"path"
@@ -16,8 +22,10 @@ import (
 	"runtime"
 	"slices"
 	"strings"
+	"sync"
 
 	"github.com/alranel/go-vcsurl/v2"
+	httpclient "github.com/italia/httpclient-lib-go"
 	"github.com/italia/publiccode-parser-go/v5/data"
 	netutil "github.com/italia/publiccode-parser-go/v5/internal"
 )
@@ -28,6 +36,10 @@ func init() {
 
 var errMissingURLScheme = errors.New("missing URL scheme")
 
+// errCheckAborted is wrapped by the errors of the external checks that
+// couldn't run to completion because the parse context was done.
+var errCheckAborted = errors.New("check aborted")
+
 var oembedSchemes []*regexp.Regexp
 
 func init() {
@@ -78,9 +90,134 @@ func getHeaderFromDomain(domain Domain, url string) map[string]string {
 	return headers
 }
 
+// contextDoer is an httpclient.HTTPDoer binding every request to ctx, since
+// httpclient builds its requests without a context.
+type contextDoer struct {
+	ctx    context.Context //nolint:containedctx // the doer lives as long as a single parse
+	client *http.Client
+}
+
+func (d contextDoer) Do(req *http.Request) (*http.Response, error) {
+	return d.client.Do(req.WithContext(d.ctx)) //nolint:wrapcheck // httpclient inspects the error itself
+}
+
+// httpClient returns an httpclient.Client whose requests are bound to ctx.
+func (p *Parser) httpClient(ctx context.Context) *httpclient.Client {
+	return httpclient.NewClient(contextDoer{ctx: ctx, client: p.client})
+}
+
+// checkAborted returns an error wrapping errCheckAborted if ctx is done, nil otherwise.
+func checkAborted(ctx context.Context) error {
+	if ctx.Err() == nil {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %w", errCheckAborted, context.Cause(ctx))
+}
+
+// runWithContext runs fn and returns its result, or returns early with an
+// error wrapping errCheckAborted if ctx is done first.
+//
+// It's meant for go-vcsurl, which doesn't take a context: the abandoned call
+// keeps going in the background until the HTTP client timeout.
+func runWithContext[T any](ctx context.Context, fn func() (T, error)) (T, error) {
+	var zero T
+
+	if err := checkAborted(ctx); err != nil {
+		return zero, err
+	}
+
+	type result struct {
+		v   T
+		err error
+	}
+
+	done := make(chan result, 1)
+
+	go func() {
+		v, err := fn()
+		done <- result{v, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.v, r.err
+	case <-ctx.Done():
+		return zero, checkAborted(ctx)
+	}
+}
+
+// externalCheck is a check on a resource referenced by the publiccode.yml,
+// such as a remote URL or a local file. It returns the problems found, if any.
+type externalCheck func(ctx context.Context) ValidationResults
+
+// runExternalChecks runs checks concurrently, up to MaxConcurrentChecks at a time,
+// and returns their results in the same order as checks.
+func (p *Parser) runExternalChecks(ctx context.Context, checks []externalCheck) ValidationResults {
+	results := make([]ValidationResults, len(checks))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for range min(p.maxConcurrentChecks, len(checks)) {
+		wg.Go(func() {
+			for i := range jobs {
+				results[i] = checks[i](ctx)
+			}
+		})
+	}
+
+	for i := range checks {
+		jobs <- i
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	return slices.Concat(results...)
+}
+
+// cacheKey returns the ReachabilityCache key of check for u. Since the cache
+// can be shared by parsers with different Domain settings, the key includes
+// a fingerprint of the credentials sent to u's host, if any.
+func (p *Parser) cacheKey(check string, u *url.URL) string {
+	key := check + " " + u.String()
+
+	if isHostInDomain(p.domain, u.String()) {
+		sum := sha256.Sum256([]byte(strings.Join(p.domain.BasicAuth, "\n")))
+		key += " auth:" + hex.EncodeToString(sum[:8])
+	}
+
+	return key
+}
+
+// cachedCheck returns the result of check for key from the ReachabilityCache,
+// if any, or runs check and stores its result otherwise.
+//
+// check returns an error when it couldn't run to completion, in which case
+// there's no result to cache.
+func (p *Parser) cachedCheck(key string, check func() (ReachabilityResult, error)) (ReachabilityResult, error) {
+	if p.cache != nil {
+		if result, ok := p.cache.Get(key); ok {
+			return result, nil
+		}
+	}
+
+	result, err := check()
+	if err != nil {
+		return result, err
+	}
+
+	if p.cache != nil {
+		p.cache.Set(key, result)
+	}
+
+	return result, nil
+}
+
 // isReachable checks whether the URL resource is reachable.
 // An URL resource is reachable if it returns HTTP 200.
-func (p *Parser) isReachable(u url.URL) (bool, error) {
+func (p *Parser) isReachable(ctx context.Context, u url.URL) (bool, error) {
 	// Don't check if we are running in WASM because we'd most likely
 	// fail due to CORS errors.
 	if runtime.GOARCH == "wasm" {
@@ -91,20 +228,88 @@ func (p *Parser) isReachable(u url.URL) (bool, error) {
 		return false, errMissingURLScheme
 	}
 
-	_, err := p.httpclient.GetURL(u.String(), getHeaderFromDomain(p.domain, u.String()))
+	result, err := p.cachedCheck(p.cacheKey("reachable", &u), func() (ReachabilityResult, error) {
+		if err := checkAborted(ctx); err != nil {
+			return ReachabilityResult{}, err
+		}
+
+		_, err := p.httpClient(ctx).GetURL(u.String(), getHeaderFromDomain(p.domain, u.String()))
+		if err != nil {
+			if abortErr := checkAborted(ctx); abortErr != nil {
+				return ReachabilityResult{}, abortErr
+			}
+
+			return ReachabilityResult{Error: err.Error()}, nil
+		}
+
+		return ReachabilityResult{OK: true}, nil
+	})
 	if err != nil {
-		return false, fmt.Errorf("HTTP GET failed for %s: %w", u.String(), err)
+		return false, err
+	}
+
+	if !result.OK {
+		return false, errors.New(p.message("http_get_failed", u.String(), result.Error)) //nolint:err113 // dynamic message
 	}
 
 	return true, nil
 }
 
+// getRawRoot returns the URL of the raw files of the code repository at u,
+// for the branch set in ParserConfig.
+func (p *Parser) getRawRoot(ctx context.Context, u *url.URL) (*url.URL, error) {
+	result, err := p.cachedCheck(p.cacheKey("rawroot "+p.branch, u), func() (ReachabilityResult, error) {
+		rawRoot, err := runWithContext(ctx, func() (*url.URL, error) {
+			return vcsurl.GetRawRoot(u, p.branch)
+		})
+
+		switch {
+		case errors.Is(err, errCheckAborted):
+			return ReachabilityResult{}, err
+		case err != nil:
+			return ReachabilityResult{Error: err.Error()}, nil
+		}
+
+		return ReachabilityResult{OK: true, Value: rawRoot.String()}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !result.OK {
+		return nil, errors.New(result.Error) //nolint:err113 // the message may come from the cache
+	}
+
+	rawRoot, err := url.Parse(result.Value)
+	if err != nil {
+		return nil, fmt.Errorf("parsing raw URL %q: %w", result.Value, err)
+	}
+
+	return rawRoot, nil
+}
+
+// isRepo returns whether u is the URL of a code repository.
+func (p *Parser) isRepo(ctx context.Context, u *url.URL) (bool, error) {
+	result, err := p.cachedCheck(p.cacheKey("repo", u), func() (ReachabilityResult, error) {
+		isRepo, err := runWithContext(ctx, func() (bool, error) {
+			return vcsurl.IsRepo(u), nil
+		})
+		if err != nil {
+			return ReachabilityResult{}, err
+		}
+
+		return ReachabilityResult{OK: isRepo}, nil
+	})
+
+	return result.OK, err
+}
+
 // toAbsoluteURL turns the passed string into an URL, trying to resolve
 // code hosting URLs to their raw URL.
 //
 // It supports relative paths and turns them into remote URLs or file:// URLs
 // depending on the value of baseURL.
-func toAbsoluteURL(file string, baseURL *url.URL, network bool) *url.URL {
+func toAbsoluteURL(ctx context.Context, file string, baseURL *url.URL, network bool) *url.URL {
 	// Check if file is an absolute URL
 	if uri, err := url.ParseRequestURI(file); err == nil {
 		if !network {
@@ -112,7 +317,10 @@ func toAbsoluteURL(file string, baseURL *url.URL, network bool) *url.URL {
 		}
 
 		// this uses the network to detect the git branch
-		if raw := vcsurl.GetRawFile(uri); raw != nil {
+		raw, _ := runWithContext(ctx, func() (*url.URL, error) {
+			return vcsurl.GetRawFile(uri), nil
+		})
+		if raw != nil {
 			return raw
 		}
 
@@ -129,25 +337,39 @@ func toAbsoluteURL(file string, baseURL *url.URL, network bool) *url.URL {
 }
 
 // fileExists returns true if the file resource exists.
-func (p *Parser) fileExists(u url.URL, network bool) (bool, error) {
+func (p *Parser) fileExists(ctx context.Context, u url.URL, network bool) (bool, error) {
+	// Relative files looked up in the fs.FS set in ParserConfig
+	if u.Scheme == netutil.FSScheme {
+		_, err := fs.Stat(p.baseFS, fsName(u))
+		if err != nil {
+			err = errors.New(p.message("no_such_file", netutil.DisplayURL(&u))) //nolint:err113 // dynamic message
+		}
+
+		return err == nil, err
+	}
+
 	// Don't check if we are running in WASM because there's no stat(2) there
 	if runtime.GOARCH == "wasm" {
 		return true, nil
 	}
 
+	if err := checkAborted(ctx); err != nil {
+		return false, err
+	}
+
 	// If we have an absolute local path, perform validation on it, otherwise do it
 	// on the remote URL if any. If none are available, validation is skipped.
 	if u.Scheme == "file" {
 		_, err := os.Stat(u.Path) //nolint:gosec // G703: path is from a validated file:// URL
 		if err != nil {
-			err = fmt.Errorf("no such file: %s", netutil.DisplayURL(&u)) //nolint:err113 // dynamic message with path context
+			err = errors.New(p.message("no_such_file", netutil.DisplayURL(&u))) //nolint:err113 // dynamic message
 		}
 
 		return err == nil, err
 	}
 
 	if network {
-		reachable, err := p.isReachable(u)
+		reachable, err := p.isReachable(ctx, u)
 
 		return reachable, err
 	}
@@ -155,45 +377,65 @@ func (p *Parser) fileExists(u url.URL, network bool) (bool, error) {
 	return true, nil
 }
 
+// fsName returns the name in the base fs.FS of the file at u, an URL with
+// the FSScheme scheme.
+func fsName(u url.URL) string {
+	if name := strings.TrimPrefix(path.Clean(u.Path), "/"); name != "" {
+		return name
+	}
+
+	return "."
+}
+
 // isImageFile check whether the string is a valid image. It also checks if the file exists.
 // It returns true if it is an image or false if it's not and an error, if any.
-func (p *Parser) isImageFile(u url.URL, network bool) (bool, error) {
+func (p *Parser) isImageFile(ctx context.Context, u url.URL, network bool) (bool, error) {
 	validExt := []string{".jpg", ".png"}
 	ext := strings.ToLower(filepath.Ext(u.Path))
 
 	if !slices.Contains(validExt, ext) {
-		return false, fmt.Errorf("invalid file extension for: %s", netutil.DisplayURL(&u)) //nolint:err113,lll // dynamic message with path context
+		return false, errors.New(p.message("invalid_file_extension", netutil.DisplayURL(&u))) //nolint:err113,lll // dynamic message
 	}
 
-	return p.fileExists(u, network)
+	return p.fileExists(ctx, u, network)
 }
 
 // validLogo returns true if the file path in value is a valid logo.
 // It also checks if the file exists.
-func (p *Parser) validLogo(u url.URL, network bool) (bool, error) {
+func (p *Parser) validLogo(ctx context.Context, u url.URL, network bool) (bool, error) {
 	validExt := []string{".svg", ".svgz", ".png"}
 	ext := strings.ToLower(filepath.Ext(u.Path))
 
 	// Check for valid extension.
 	if !slices.Contains(validExt, ext) {
-		return false, fmt.Errorf("invalid file extension for: %s", netutil.DisplayURL(&u)) //nolint:err113,lll // dynamic message with path context
+		return false, errors.New(p.message("invalid_file_extension", netutil.DisplayURL(&u))) //nolint:err113,lll // dynamic message
 	}
 
-	if exists, err := p.fileExists(u, network); !exists {
+	if exists, err := p.fileExists(ctx, u, network); !exists {
 		return false, err
 	}
 
 	var localPath string
-	// Remote. Create a temp dir, download and check the file. Remove the temp dir.
-	if u.Scheme != "file" {
+
+	switch u.Scheme {
+	case "file":
+		localPath = u.Path
+	case netutil.FSScheme:
+		// Read straight from the fs.FS set in ParserConfig.
+	default:
+		// Remote. Create a temp dir, download and check the file. Remove the temp dir.
 		var err error
 
 		if !network {
 			return true, nil
 		}
 
-		localPath, err = netutil.DownloadTmpFile(p.httpclient, &u, getHeaderFromDomain(p.domain, u.String()))
+		localPath, err = netutil.DownloadTmpFile(p.httpClient(ctx), &u, getHeaderFromDomain(p.domain, u.String()))
 		if err != nil {
+			if abortErr := checkAborted(ctx); abortErr != nil {
+				return false, abortErr
+			}
+
 			return false, fmt.Errorf("downloading %s: %w", u.String(), err)
 		}
 
@@ -211,12 +453,21 @@ func (p *Parser) validLogo(u url.URL, network bool) (bool, error) {
 				fmt.Fprintf(os.Stderr, "failed to remove %s: %v\n", dir, err)
 			}
 		}()
-	} else {
-		localPath = u.Path
 	}
 
 	if ext == ".png" {
-		f, err := os.Open(localPath) //nolint:gosec // G703: path from validated download
+		var (
+			f   io.ReadCloser
+			err error
+		)
+
+		if u.Scheme == netutil.FSScheme {
+			localPath = netutil.DisplayURL(&u)
+			f, err = p.baseFS.Open(fsName(u))
+		} else {
+			f, err = os.Open(localPath) //nolint:gosec // G703: path from validated download
+		}
+
 		if err != nil {
 			return false, fmt.Errorf("opening %s: %w", localPath, err)
 		}
@@ -224,6 +475,10 @@ func (p *Parser) validLogo(u url.URL, network bool) (bool, error) {
 		defer f.Close()
 
 		if _, _, err := image.DecodeConfig(f); err != nil {
+			if errors.Is(err, image.ErrFormat) {
+				return false, errors.New(p.message("unknown_image_format")) //nolint:err113 // dynamic message
+			}
+
 			return false, fmt.Errorf("%w", err)
 		}
 	}
@@ -247,7 +502,7 @@ func (p *Parser) checkOEmbedURL(url *url.URL) error {
 	link := url.String()
 
 	if !matchesOembedScheme(link) {
-		return fmt.Errorf("invalid oEmbed link: %s", link) //nolint:err113 // dynamic message with URL context
+		return errors.New(p.message("invalid_oembed_link", link)) //nolint:err113 // dynamic message
 	}
 
 	return nil
@@ -1,11 +1,15 @@
 package publiccode
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestGetBasicAuth(t *testing.T) {
@@ -81,7 +85,7 @@ func TestGetHeaderFromDomainMatch(t *testing.T) {
 
 func TestIsReachableMissingScheme(t *testing.T) {
 	p, _ := NewParser(ParserConfig{DisableNetwork: true})
-	_, err := p.isReachable(url.URL{Scheme: "", Host: "example.com", Path: "/"})
+	_, err := p.isReachable(context.Background(), url.URL{Scheme: "", Host: "example.com", Path: "/"})
 	if err == nil {
 		t.Fatal("expected error for missing scheme")
 	}
@@ -98,7 +102,7 @@ func TestIsReachableNon200(t *testing.T) {
 
 	p, _ := NewParser(ParserConfig{AllowNetworkToPrivateHosts: true})
 	parsed, _ := url.Parse(srv.URL + "/path")
-	reachable, err := p.isReachable(*parsed)
+	reachable, err := p.isReachable(context.Background(), *parsed)
 	if reachable {
 		t.Error("expected not reachable for 404 response")
 	}
@@ -110,7 +114,7 @@ func TestIsReachableNon200(t *testing.T) {
 func TestIsImageFileInvalidExtension(t *testing.T) {
 	p, _ := NewParser(ParserConfig{DisableNetwork: true})
 	u := url.URL{Scheme: "file", Path: "/tmp/test.gif"}
-	ok, err := p.isImageFile(u, false)
+	ok, err := p.isImageFile(context.Background(), u, false)
 	if ok {
 		t.Error("expected false for .gif extension")
 	}
@@ -122,7 +126,7 @@ func TestIsImageFileInvalidExtension(t *testing.T) {
 func TestIsImageFileValidExtensionMissing(t *testing.T) {
 	p, _ := NewParser(ParserConfig{DisableNetwork: true})
 	u := url.URL{Scheme: "file", Path: "/nonexistent/test.png"}
-	ok, err := p.isImageFile(u, false)
+	ok, err := p.isImageFile(context.Background(), u, false)
 	if ok {
 		t.Error("expected false for nonexistent file")
 	}
@@ -134,7 +138,7 @@ func TestIsImageFileValidExtensionMissing(t *testing.T) {
 func TestValidLogoInvalidExtension(t *testing.T) {
 	p, _ := NewParser(ParserConfig{DisableNetwork: true})
 	u := url.URL{Scheme: "file", Path: "/tmp/test.gif"}
-	ok, err := p.validLogo(u, false)
+	ok, err := p.validLogo(context.Background(), u, false)
 	if ok {
 		t.Error("expected false for .gif extension")
 	}
@@ -146,7 +150,7 @@ func TestValidLogoInvalidExtension(t *testing.T) {
 func TestValidLogoMissingFile(t *testing.T) {
 	p, _ := NewParser(ParserConfig{DisableNetwork: true})
 	u := url.URL{Scheme: "file", Path: "/nonexistent/logo.svg"}
-	ok, err := p.validLogo(u, false)
+	ok, err := p.validLogo(context.Background(), u, false)
 	if ok {
 		t.Error("expected false for nonexistent file")
 	}
@@ -166,7 +170,7 @@ func TestValidLogoRemoteNoNetwork(t *testing.T) {
 
 	parsed, _ := url.Parse(srv.URL + "/logo.svg")
 	// network=false: fileExists returns true for non-file scheme, and validLogo skips download
-	ok, err := p.validLogo(*parsed, false)
+	ok, err := p.validLogo(context.Background(), *parsed, false)
 	if !ok {
 		t.Errorf("expected true for remote SVG with network=false (no download): %v", err)
 	}
@@ -187,7 +191,7 @@ func TestValidLogoRemoteDownloadFailure(t *testing.T) {
 
 	p, _ := NewParser(ParserConfig{AllowNetworkToPrivateHosts: true})
 	parsed, _ := url.Parse(srv.URL + "/logo.png")
-	ok, err := p.validLogo(*parsed, true)
+	ok, err := p.validLogo(context.Background(), *parsed, true)
 	// The downloaded file is not a valid PNG, so DecodeConfig should fail.
 	if ok {
 		t.Error("expected false for invalid PNG content")
@@ -197,7 +201,6 @@ func TestValidLogoRemoteDownloadFailure(t *testing.T) {
 	}
 }
 
-
 func TestIsReachableSuccess(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -206,8 +209,73 @@ func TestIsReachableSuccess(t *testing.T) {
 
 	p, _ := NewParser(ParserConfig{AllowNetworkToPrivateHosts: true})
 	parsed, _ := url.Parse(srv.URL + "/path")
-	reachable, err := p.isReachable(*parsed)
+	reachable, err := p.isReachable(context.Background(), *parsed)
 	if !reachable {
 		t.Errorf("expected reachable for 200 response, got err: %v", err)
 	}
 }
+
+func TestRunExternalChecksBoundedAndOrdered(t *testing.T) {
+	const limit = 3
+
+	p, err := NewParser(ParserConfig{MaxConcurrentChecks: limit})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		mu       sync.Mutex
+		inFlight int
+		maxSeen  int
+	)
+
+	checks := make([]externalCheck, 20)
+	for i := range checks {
+		checks[i] = func(context.Context) ValidationResults {
+			mu.Lock()
+			inFlight++
+			maxSeen = max(maxSeen, inFlight)
+			mu.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+
+			return ValidationResults{newValidationError(fmt.Sprintf("key%02d", i), "failed")}
+		}
+	}
+
+	results := p.runExternalChecks(context.Background(), checks)
+
+	if maxSeen > limit {
+		t.Errorf("expected at most %d checks in flight, got %d", limit, maxSeen)
+	}
+	if maxSeen < 2 {
+		t.Errorf("expected checks to run concurrently, got at most %d in flight", maxSeen)
+	}
+
+	if len(results) != len(checks) {
+		t.Fatalf("expected %d results, got %d", len(checks), len(results))
+	}
+	for i, res := range results {
+		if want := fmt.Sprintf("key%02d", i); res.(ValidationError).Key != want {
+			t.Errorf("result %d: expected key %s, got %s", i, want, res.(ValidationError).Key)
+		}
+	}
+}
+
+func TestRunExternalChecksEmpty(t *testing.T) {
+	p, _ := NewParser(ParserConfig{})
+	if results := p.runExternalChecks(context.Background(), nil); results != nil {
+		t.Errorf("expected no results, got %v", results)
+	}
+}
+
+func TestDefaultMaxConcurrentChecks(t *testing.T) {
+	p, _ := NewParser(ParserConfig{})
+	if p.maxConcurrentChecks != defaultMaxConcurrentChecks {
+		t.Errorf("expected %d, got %d", defaultMaxConcurrentChecks, p.maxConcurrentChecks)
+	}
+}
@@ -36,6 +36,24 @@ func isIso3166Alpha2LowerOrUpper(fl validator.FieldLevel) bool {
 	return err == nil
 }
 
+// countryCodes returns the country codes accepted by
+// iso3166_1_alpha2_lower_or_upper, uppercase first.
+func countryCodes() []string {
+	var upper, lower []string
+
+	for a := 'A'; a <= 'Z'; a++ {
+		for b := 'A'; b <= 'Z'; b++ {
+			code := string([]rune{a, b})
+			if sharedValidator.Var(code, "iso3166_1_alpha2") == nil {
+				upper = append(upper, code)
+				lower = append(lower, strings.ToLower(code))
+			}
+		}
+	}
+
+	return append(upper, lower...)
+}
+
 func uMax(fl validator.FieldLevel) bool {
 	length := uniseg.GraphemeClusterCount(fl.Field().String())
 	param, _ := strconv.Atoi(fl.Param())
@@ -0,0 +1,36 @@
+package validators
+
+// localMessages are the translations of the messages of the validators, by
+// locale and key. The messages with no translation are in English.
+var localMessages = map[string]map[string]string{
+	"it": {
+		"oneof":            "{0} deve essere uno dei seguenti valori: {1}{2}",
+		"or":               "{0} o {1}",
+		"did_you_mean":     ", forse intendevi '{0}'?",
+		"date":             "{0} deve essere una data nel formato 'AAAA-MM-GG'",
+		"required_if":      "{0} è un campo obbligatorio quando \"{1}\" è \"{2}\"",
+		"is_mime_type":     "{0} non è un tipo MIME valido",
+		"excluded_unless":  "{0} non deve essere presente a meno che \"{1}\" sia \"{2}\"",
+		"umax":             "{0} deve essere lungo al massimo {1} caratteri",
+		"umin":             "{0} deve essere lungo almeno {1} caratteri",
+		"organisation_uri": "{0} non è un URI valido",
+		"organisation_uri_invalid_italian_pa": "{0} deve essere un codice iPA (Indice delle Pubbliche Amministrazioni) " +
+			"valido nel formato 'urn:x-italian-pa:[codiceIPA]' " +
+			"(vedi https://github.com/publiccodeyml/italian-organizations-ipa-vocabulary)",
+		"url_http_url":       "{0} deve essere un URL HTTP",
+		"url_url":            "{0} deve essere un URL valido",
+		"is_spdx_expression": "{0} deve essere una licenza valida (vedi https://spdx.org/licenses)",
+		"is_category_v0": "{0} deve essere una categoria valida{1} " +
+			"(vedi https://github.com/publiccodeyml/publiccode.yml/blob/main/docs/standard/categories-list.rst)",
+		"is_scope_v0": "{0} deve essere un ambito valido{1} " +
+			"(vedi https://github.com/publiccodeyml/publiccode.yml/blob/main/docs/standard/scope-list.rst)",
+		"supports_id": "{0} deve essere un alias noto ('alias:<nome>') o un URI valido (URL o URN)",
+		"supports_id_unknown_alias": "{0} contiene un alias sconosciuto " +
+			"(vedi https://github.com/publiccodeyml/publiccode.yml/blob/main/docs/standard/aliases-list.rst)",
+		"is_italian_ipa_code": "{0} deve essere un codice iPA (Indice delle Pubbliche Amministrazioni) valido " +
+			"(vedi https://github.com/publiccodeyml/italian-organizations-ipa-vocabulary)",
+		"iso3166_1_alpha2_lower_or_upper": "{0} deve essere un codice paese ISO 3166-1 alpha-2 di due lettere valido",
+		"bcp47_strict_language_tag":       "{0} deve essere una lingua BCP 47 valida",
+		"bcp47_keys":                      "{0} deve essere una lingua BCP 47 valida",
+	},
+}
@@ -0,0 +1,85 @@
+package validators
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	ut "github.com/go-playground/universal-translator"
+)
+
+// Suggest returns the candidate closest to value, if it's close enough to be
+// a likely typo of it (eg. "stable" for "stabel"). Ties go to the first
+// candidate.
+func Suggest(value string, candidates []string) (string, bool) {
+	// Short values (eg. "1.1") are too close to everything, just match them
+	// ignoring case.
+	maxDistance := 0
+	if n := utf8.RuneCountInString(value); n >= 4 {
+		maxDistance = n / 3
+	}
+
+	best, bestDistance := "", maxDistance+1
+
+	for _, c := range candidates {
+		if d := editDistance(strings.ToLower(value), strings.ToLower(c)); d < bestDistance {
+			best, bestDistance = c, d
+		}
+	}
+
+	return best, best != ""
+}
+
+// editDistance returns the optimal string alignment distance between a and b:
+// the number of insertions, deletions, substitutions and transpositions of
+// adjacent characters turning a into b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	// Three rows of the full matrix are enough.
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	return prev[len(rb)]
+}
+
+// suggestion returns the hint, in the language of trans, with the candidate
+// closest to value (eg. ", did you mean 'stable'?"), or "" if there's none.
+func suggestion(trans ut.Translator, value any, candidates []string) string {
+	s, ok := value.(string)
+	if !ok || s == "" {
+		return ""
+	}
+
+	closest, ok := Suggest(s, candidates)
+	if !ok || closest == s {
+		return ""
+	}
+
+	hint, _ := trans.T("did_you_mean", closest)
+
+	return hint
+}
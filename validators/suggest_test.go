@@ -0,0 +1,33 @@
+package validators
+
+import "testing"
+
+func TestSuggest(t *testing.T) {
+	candidates := []string{"concept", "development", "beta", "stable", "obsolete"}
+
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"stabel", "stable"},
+		{"Stable", "stable"},
+		{"developement", "development"},
+		{"obsolote", "obsolete"},
+		{"alpha", ""},
+		{"bet", ""},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		got, ok := Suggest(test.value, candidates)
+		if got != test.expected || ok != (test.expected != "") {
+			t.Errorf("Suggest(%q) = %q, %v, want %q", test.value, got, ok, test.expected)
+		}
+	}
+}
+
+func TestSuggestTies(t *testing.T) {
+	if got, _ := Suggest("It", []string{"IT", "it"}); got != "IT" {
+		t.Errorf("expected the first candidate, got %q", got)
+	}
+}
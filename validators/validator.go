@@ -2,7 +2,9 @@ package validators
 
 import (
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
 	"strings"
 
 	ut "github.com/go-playground/universal-translator"
@@ -43,6 +45,44 @@ func New() *validator.Validate {
 	return validate
 }
 
+// Vocabulary returns the sorted values the validation tag (eg.
+// "is_category_v0") checks against a fixed list. For supports_id, these are
+// the aliases only, any other URI is accepted too.
+func Vocabulary(tag string) ([]string, bool) {
+	switch tag {
+	case "is_category_v0":
+		return slices.Sorted(maps.Keys(supportedCategoriesV0)), true
+	case "is_scope_v0":
+		return slices.Sorted(maps.Keys(supportedScopesV0)), true
+	case "supports_id":
+		aliases := make([]string, 0, len(supportsAliasesV0))
+		for alias := range supportsAliasesV0 {
+			aliases = append(aliases, "alias:"+alias)
+		}
+
+		slices.Sort(aliases)
+
+		return aliases, true
+	case "iso3166_1_alpha2_lower_or_upper":
+		return countryCodes(), true
+	}
+
+	return nil, false
+}
+
+// Pattern returns the regular expression the validation tag (eg.
+// "is_mime_type") matches the values against, if any.
+func Pattern(tag string) (string, bool) {
+	if tag == "is_mime_type" {
+		return reMIMEType.String(), true
+	}
+
+	return "", false
+}
+
+// RegisterLocalErrorMessages registers in v the messages of the validators
+// of this package, in the language of trans if there's a translation for it
+// (eg. "it"), in English otherwise.
 func RegisterLocalErrorMessages(v *validator.Validate, trans ut.Translator) error {
 	var err error
 
@@ -61,19 +101,23 @@ func RegisterLocalErrorMessages(v *validator.Validate, trans ut.Translator) erro
 			// overridden:
 			//   foo must be one of the following: "foo", "bar" or "baz"
 			tag:         "oneof",
-			translation: "{0} must be one of the following: {1}",
+			translation: "{0} must be one of the following: {1}{2}",
 			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
 				parts := strings.Fields(fe.Param())
-				s := `"` + strings.Join(parts, `", "`) + `"`
 
-				if len(parts) > 1 {
-					i := strings.LastIndex(s, `", "`)
-					s = s[:i] + `" or "` + s[i+4:]
+				quoted := make([]string, len(parts))
+				for i, part := range parts {
+					quoted[i] = `"` + part + `"`
 				}
 
-				s, _ = ut.T(fe.Tag(), fe.Field(), s)
+				s := quoted[len(quoted)-1]
+				if len(quoted) > 1 {
+					s, _ = ut.T("or", strings.Join(quoted[:len(quoted)-1], ", "), s)
+				}
 
-				return s
+				t, _ := ut.T(fe.Tag(), fe.Field(), s, suggestion(ut, fe.Value(), parts))
+
+				return t
 			},
 			override: true,
 		},
@@ -90,12 +134,12 @@ func RegisterLocalErrorMessages(v *validator.Validate, trans ut.Translator) erro
 			//   foo is a required field when "bar" is "foobar"
 			tag: "required_if",
 			customRegisFunc: func(ut ut.Translator) error {
-				return ut.Add("required_if", "{0} is a required field when {1}", true)
+				return add(ut, "required_if", "{0} is a required field when \"{1}\" is \"{2}\"", true)
 			},
 			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
 				parts := strings.Fields(fe.Param())
 
-				t, _ := ut.T("required_if", fe.Field(), fmt.Sprintf("\"%s\" is \"%s\"", strings.ToLower(parts[0]), parts[1]))
+				t, _ := ut.T("required_if", fe.Field(), strings.ToLower(parts[0]), parts[1])
 
 				return t
 			},
@@ -114,12 +158,12 @@ func RegisterLocalErrorMessages(v *validator.Validate, trans ut.Translator) erro
 			//   foo is not permitted when "bar" is "foobar"
 			tag: "excluded_unless",
 			customRegisFunc: func(ut ut.Translator) error {
-				return ut.Add("excluded_unless", "{0} must not be present unless {1}", true)
+				return add(ut, "excluded_unless", "{0} must not be present unless \"{1}\" is \"{2}\"", true)
 			},
 			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
 				parts := strings.Fields(fe.Param())
 
-				t, _ := ut.T("excluded_unless", fe.Field(), fmt.Sprintf("\"%s\" is \"%s\"", strings.ToLower(parts[0]), parts[1]))
+				t, _ := ut.T("excluded_unless", fe.Field(), strings.ToLower(parts[0]), parts[1])
 
 				return t
 			},
@@ -128,7 +172,7 @@ func RegisterLocalErrorMessages(v *validator.Validate, trans ut.Translator) erro
 		{
 			tag: "umax",
 			customRegisFunc: func(ut ut.Translator) error {
-				return ut.Add("umax", "{0} must be a maximum of {1} characters in length", false)
+				return add(ut, "umax", "{0} must be a maximum of {1} characters in length", false)
 			},
 			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
 				t, _ := ut.T("umax", fe.Field(), fe.Param())
@@ -139,7 +183,7 @@ func RegisterLocalErrorMessages(v *validator.Validate, trans ut.Translator) erro
 		{
 			tag: "umin",
 			customRegisFunc: func(ut ut.Translator) error {
-				return ut.Add("umin", "{0} must be at least {1} characters in length", false)
+				return add(ut, "umin", "{0} must be at least {1} characters in length", false)
 			},
 			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
 				t, _ := ut.T("umin", fe.Field(), fe.Param())
@@ -150,12 +194,13 @@ func RegisterLocalErrorMessages(v *validator.Validate, trans ut.Translator) erro
 		{
 			tag: "organisation_uri",
 			customRegisFunc: func(ut ut.Translator) error {
-				err := ut.Add("organisation_uri", "{0} is not a valid URI", false)
+				err := add(ut, "organisation_uri", "{0} is not a valid URI", false)
 				if err != nil {
 					return fmt.Errorf("registering translation: %w", err)
 				}
 
-				err = ut.Add(
+				err = add(
+					ut,
 					"organisation_uri_invalid_italian_pa",
 					"{0} must be a valid Italian Public Administration Code (iPA) with format 'urn:x-italian-pa:[codiceIPA]' (see https://github.com/publiccodeyml/italian-organizations-ipa-vocabulary)", //nolint:lll // long URL in message
 					false)
@@ -194,16 +239,27 @@ func RegisterLocalErrorMessages(v *validator.Validate, trans ut.Translator) erro
 		},
 		{
 			tag:         "is_category_v0",
-			translation: "{0} must be a valid category (see https://github.com/publiccodeyml/publiccode.yml/blob/main/docs/standard/categories-list.rst)", //nolint:lll // long URL in message
+			translation: "{0} must be a valid category{1} (see https://github.com/publiccodeyml/publiccode.yml/blob/main/docs/standard/categories-list.rst)", //nolint:lll // long URL in message
+			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
+				t, _ := ut.T(fe.Tag(), fe.Field(), suggestion(ut, fe.Value(), slices.Sorted(maps.Keys(supportedCategoriesV0))))
+
+				return t
+			},
 		},
 		{
 			tag:         "is_scope_v0",
-			translation: "{0} must be a valid scope (see https://github.com/publiccodeyml/publiccode.yml/blob/main/docs/standard/scope-list.rst)", //nolint:lll // long URL in message
+			translation: "{0} must be a valid scope{1} (see https://github.com/publiccodeyml/publiccode.yml/blob/main/docs/standard/scope-list.rst)", //nolint:lll // long URL in message
+			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
+				t, _ := ut.T(fe.Tag(), fe.Field(), suggestion(ut, fe.Value(), slices.Sorted(maps.Keys(supportedScopesV0))))
+
+				return t
+			},
 		},
 		{
 			tag: "supports_id",
 			customRegisFunc: func(ut ut.Translator) error {
-				if err := ut.Add(
+				if err := add(
+					ut,
 					"supports_id",
 					"{0} must be a known alias ('alias:<name>') or a valid URI (URL or URN)",
 					false,
@@ -211,7 +267,8 @@ func RegisterLocalErrorMessages(v *validator.Validate, trans ut.Translator) erro
 					return fmt.Errorf("registering translation: %w", err)
 				}
 
-				if err := ut.Add(
+				if err := add(
+					ut,
 					"supports_id_unknown_alias",
 					"{0} contains an unknown alias (see https://github.com/publiccodeyml/publiccode.yml/blob/main/docs/standard/aliases-list.rst)", //nolint:lll // long URL in message
 					false,
@@ -272,12 +329,31 @@ func RegisterLocalErrorMessages(v *validator.Validate, trans ut.Translator) erro
 		}
 	}
 
+	// The parts of the messages above.
+	if err := add(trans, "or", "{0} or {1}", false); err != nil {
+		return fmt.Errorf("registering translation: %w", err)
+	}
+
+	if err := add(trans, "did_you_mean", ", did you mean '{0}'?", false); err != nil {
+		return fmt.Errorf("registering translation: %w", err)
+	}
+
 	return nil
 }
 
+// add adds the message for key to trans, in the language of trans if it's
+// in localMessages, in English otherwise.
+func add(trans ut.Translator, key string, text string, override bool) error {
+	if message, ok := localMessages[trans.Locale()][key]; ok {
+		text = message
+	}
+
+	return trans.Add(key, text, override) //nolint:wrapcheck // the callers wrap it
+}
+
 func registrationFunc(tag string, translation string, override bool) validator.RegisterTranslationsFunc {
 	return func(ut ut.Translator) error {
-		if err := ut.Add(tag, translation, override); err != nil {
+		if err := add(ut, tag, translation, override); err != nil {
 			//nolint:forbidigo // programming error caught at runtime, it's right to panic
 			panic(fmt.Sprintf("failed to register translation for tag %q: %s", tag, err.Error()))
 		}
@@ -1,11 +1,16 @@
 package validators
 
 import (
+	"errors"
+	"slices"
 	"testing"
 
 	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/it"
 	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
 	en_translations "github.com/go-playground/validator/v10/translations/en"
+	it_translations "github.com/go-playground/validator/v10/translations/it"
 )
 
 func TestNewReturnsNonNil(t *testing.T) {
@@ -28,6 +33,44 @@ func TestRegisterLocalErrorMessages(t *testing.T) {
 	}
 }
 
+func TestRegisterLocalErrorMessagesItalian(t *testing.T) {
+	v := New()
+	enLocale, itLocale := en.New(), it.New()
+	uni := ut.New(enLocale, enLocale, itLocale)
+
+	enTrans, _ := uni.GetTranslator("en")
+	_ = en_translations.RegisterDefaultTranslations(v, enTrans)
+	_ = RegisterLocalErrorMessages(v, enTrans)
+
+	trans, _ := uni.GetTranslator("it")
+	_ = it_translations.RegisterDefaultTranslations(v, trans)
+
+	if err := RegisterLocalErrorMessages(v, trans); err != nil {
+		t.Fatalf("RegisterLocalErrorMessages returned error: %v", err)
+	}
+
+	// Every Italian message translates an English one.
+	for key := range localMessages["it"] {
+		if _, err := enTrans.T(key, "{0}", "{1}", "{2}"); err != nil {
+			t.Errorf("no English message for %q", key)
+		}
+	}
+
+	type S struct {
+		Status string `validate:"oneof=stable beta" yaml:"status"`
+	}
+
+	var errs validator.ValidationErrors
+	if !errors.As(v.Struct(S{Status: "stabel"}), &errs) {
+		t.Fatal("expected validation error")
+	}
+
+	expected := `status deve essere uno dei seguenti valori: "stable" o "beta", forse intendevi 'stable'?`
+	if got := errs[0].Translate(trans); got != expected {
+		t.Errorf("got %q, want %q", got, expected)
+	}
+}
+
 func TestNewTagNameFuncWithDashTag(t *testing.T) {
 	v := New()
 
@@ -101,3 +144,18 @@ func TestTranslateFuncFallback(t *testing.T) {
 		t.Fatal("expected validation error")
 	}
 }
+
+func TestVocabulary(t *testing.T) {
+	countries, ok := Vocabulary("iso3166_1_alpha2_lower_or_upper")
+	if !ok || !slices.Contains(countries, "IT") || !slices.Contains(countries, "it") || slices.Contains(countries, "XX") {
+		t.Errorf("unexpected country codes: %v", countries)
+	}
+
+	if aliases, _ := Vocabulary("supports_id"); !slices.Contains(aliases, "alias:spid") {
+		t.Errorf("unexpected aliases: %v", aliases)
+	}
+
+	if _, ok := Vocabulary("is_spdx_expression"); ok {
+		t.Error("unexpected vocabulary for is_spdx_expression")
+	}
+}
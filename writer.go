@@ -0,0 +1,1261 @@
+package publiccode
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/goccy/go-yaml/token"
+)
+
+// WriteOptions configures WriteYAML.
+type WriteOptions struct {
+	// Canonical orders the keys as in the Standard, instead of keeping their
+	// order in the document. The keys not in the Standard go last.
+	Canonical bool
+}
+
+var (
+	// errRewrite means a node can't be changed in place, and the key or item
+	// holding it has to be written again.
+	errRewrite = errors.New("can't change in place")
+
+	// errEmptied means every key of a mapping was removed.
+	errEmptied = errors.New("all keys removed")
+)
+
+const defaultIndent = 2
+
+// WriteYAML returns src, an existing publiccode.yml, changed to have the
+// values of publiccode (eg. as returned by Upgrade).
+//
+// Unlike ToYAML, it goes through the AST of src and only rewrites the text of
+// the keys and values that changed, so comments, anchors, formatting and key
+// order are kept. The keys added go after the ones preceding them in the
+// Standard, and the empty ones (eg. nil pointers) are left out.
+//
+// If src is empty, the whole publiccode.yml is written.
+func WriteYAML(src []byte, publiccode PublicCode, opts WriteOptions) ([]byte, error) {
+	value, ok := valueOf(publiccode).(mapping)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T is not a mapping", errUnsupportedEdit, publiccode)
+	}
+
+	file, err := parser.ParseBytes(src, 0)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse publiccode.yml: %w", err)
+	}
+
+	var out []byte
+
+	if len(file.Docs) == 0 || file.Docs[0].Body == nil {
+		w := newWriter(src, nil)
+		out = []byte(w.render(value.toYAML(), 0))
+	} else {
+		w := newWriter(src, file.Docs[0].Body)
+
+		err := w.mapping(file.Docs[0].Body, value)
+
+		switch {
+		case errors.Is(err, errRewrite), errors.Is(err, errEmptied):
+			// Keep what's before the document, eg. comments and "---".
+			start := 0
+			if values := mappingValues(file.Docs[0].Body); len(values) > 0 {
+				start = w.lineOffset(tokenRange(values[0].Key.GetToken()).line)
+			}
+
+			out = slices.Concat(src[:start], []byte(w.render(value.toYAML(), 0)))
+		case err != nil:
+			return nil, err
+		default:
+			out = w.apply()
+		}
+	}
+
+	if opts.Canonical {
+		return canonicalOrder(out, reflect.TypeOf(publiccode))
+	}
+
+	return out, nil
+}
+
+// mapping is a mapping to write, with its keys in the order of the Standard.
+type mapping []entry
+
+type entry struct {
+	key   string
+	value any
+}
+
+func (m mapping) get(key string) (any, bool) {
+	for _, e := range m {
+		if e.key == key {
+			return e.value, true
+		}
+	}
+
+	return nil, false
+}
+
+// toYAML returns m as a yaml.MapSlice, to marshal it in order.
+func (m mapping) toYAML() yaml.MapSlice {
+	out := make(yaml.MapSlice, 0, len(m))
+	for _, e := range m {
+		out = append(out, yaml.MapItem{Key: e.key, Value: toYAML(e.value)})
+	}
+
+	return out
+}
+
+func toYAML(v any) any {
+	switch v := v.(type) {
+	case mapping:
+		return v.toYAML()
+	case []any:
+		out := make([]any, 0, len(v))
+		for _, item := range v {
+			out = append(out, toYAML(item))
+		}
+
+		return out
+	}
+
+	return v
+}
+
+// valueOf returns publiccode as a tree of mappings, []any and scalars, leaving
+// out the empty keys.
+func valueOf(publiccode PublicCode) any {
+	switch pc := publiccode.(type) {
+	case *PublicCodeV0:
+		if pc != nil {
+			return valueOf(*pc)
+		}
+	case PublicCodeV0:
+		// The Parser sets IT to it when only the latter is in the file: write
+		// them back as they were. See ToYAML otherwise.
+		if pc.It != nil && (pc.IT == nil || pc.IT == pc.It) {
+			pc.IT = nil
+		} else {
+			pc.It = nil
+		}
+
+		return treeOf(reflect.ValueOf(pc))
+	}
+
+	return treeOf(reflect.ValueOf(publiccode))
+}
+
+type yamlMarshaler interface {
+	MarshalYAML() (any, error)
+}
+
+// treeOf is valueOf for any value, following the yaml tags of the structs.
+// Nil pointers and the zero values of the other fields are left out.
+func treeOf(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		return nil
+	}
+
+	if m, ok := v.Interface().(yamlMarshaler); ok {
+		out, err := m.MarshalYAML()
+		if err != nil {
+			return nil
+		}
+
+		return treeOf(reflect.ValueOf(out))
+	}
+
+	switch v.Kind() { //nolint:exhaustive // the other kinds are not in publiccode.yml
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+
+		return treeOf(v.Elem())
+	case reflect.Struct:
+		var m mapping
+
+		for i := range v.NumField() {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+			if name == "-" {
+				continue
+			}
+
+			if name == "" {
+				name = strings.ToLower(field.Name)
+			}
+
+			if v.Field(i).IsZero() {
+				continue
+			}
+
+			if value := treeOf(v.Field(i)); !blank(value) {
+				m = append(m, entry{name, value})
+			}
+		}
+
+		return m
+	case reflect.Map:
+		var m mapping
+
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int { return cmp.Compare(a.String(), b.String()) })
+
+		for _, key := range keys {
+			if value := treeOf(v.MapIndex(key)); !blank(value) {
+				m = append(m, entry{key.String(), value})
+			}
+		}
+
+		return m
+	case reflect.Slice, reflect.Array:
+		items := make([]any, 0, v.Len())
+		for i := range v.Len() {
+			items = append(items, treeOf(v.Index(i)))
+		}
+
+		return items
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	}
+
+	return v.Interface()
+}
+
+// scalar is a scalar in the document, with its text.
+type scalar struct {
+	value any
+	text  string
+}
+
+// stale is the value of an alias to an anchor removed from the document.
+type stale struct{}
+
+// blank returns whether v is null or an empty collection.
+func blank(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case mapping:
+		return len(v) == 0
+	case []any:
+		return len(v) == 0
+	}
+
+	return false
+}
+
+// zero returns whether v is null, empty or the zero value of its type, the
+// same as a missing key.
+func zero(v any) bool {
+	if blank(v) {
+		return true
+	}
+
+	switch v := v.(type) {
+	case scalar:
+		return zero(v.value)
+	case stale:
+		return false
+	case mapping:
+		return !slices.ContainsFunc(v, func(e entry) bool { return !zero(e.value) })
+	case []any:
+		return false
+	}
+
+	return reflect.ValueOf(v).IsZero()
+}
+
+// equal returns whether the value old, from the document, is the same as
+// value.
+func equal(old any, value any) bool {
+	switch old := old.(type) {
+	case mapping:
+		value, ok := value.(mapping)
+		if !ok {
+			return false
+		}
+
+		for _, e := range old {
+			if v, ok := value.get(e.key); ok && !equal(e.value, v) || !ok && !zero(e.value) {
+				return false
+			}
+		}
+
+		for _, e := range value {
+			if _, ok := old.get(e.key); !ok {
+				return false
+			}
+		}
+
+		return true
+	case []any:
+		// A single value is the same as a list of one (eg. isBasedOn).
+		items, ok := value.([]any)
+		if !ok {
+			return len(old) == 1 && equal(old[0], value)
+		}
+
+		return slices.EqualFunc(old, items, equal)
+	case nil:
+		return value == nil
+	case stale:
+		return false
+	}
+
+	switch value := value.(type) {
+	case mapping, nil:
+		return false
+	case []any:
+		return len(value) == 1 && equal(old, value[0])
+	}
+
+	// Compare the text too, so that eg. 1.0 is the same as "1.0".
+	if s, ok := old.(scalar); ok {
+		return fmt.Sprint(s.value) == fmt.Sprint(value) || s.text == fmt.Sprint(value)
+	}
+
+	return fmt.Sprint(old) == fmt.Sprint(value)
+}
+
+// writer changes the source of a YAML document to have new values, collecting
+// the changes to the text as splices.
+type writer struct {
+	editor
+
+	splices []splice
+
+	// The nodes of the anchors in the document, the values written in place
+	// of them and the ones removed.
+	anchors      map[string]ast.Node
+	anchorValues map[string]any
+	dropped      map[string]bool
+
+	indent         int
+	indentSequence bool
+}
+
+// splice replaces the source text from from to to (excluded) with text.
+type splice struct {
+	from, to int
+	text     string
+}
+
+func newWriter(src []byte, root ast.Node) *writer {
+	w := &writer{
+		editor:         editor{src: src, root: root},
+		anchors:        map[string]ast.Node{},
+		anchorValues:   map[string]any{},
+		dropped:        map[string]bool{},
+		indent:         defaultIndent,
+		indentSequence: true,
+	}
+
+	if root == nil {
+		return w
+	}
+
+	ast.Walk(w, root)
+
+	return w
+}
+
+// Visit collects the anchors and the indentation style of the document.
+func (w *writer) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	case *ast.AnchorNode:
+		if n.Name != nil {
+			w.anchors[n.Name.GetToken().Value] = n.Value
+		}
+	case *ast.MappingValueNode:
+		key := tokenRange(n.Key.GetToken())
+
+		switch value := n.Value.(type) {
+		case *ast.MappingNode, *ast.MappingValueNode:
+			if values := mappingValues(value); len(values) > 0 && w.indent == defaultIndent {
+				if column := tokenRange(values[0].Key.GetToken()).column; column > key.column {
+					w.indent = column - key.column
+				}
+			}
+		case *ast.SequenceNode:
+			if !value.IsFlowStyle && len(value.Entries) > 0 {
+				w.indentSequence = tokenRange(value.Entries[0].Start).column > key.column
+			}
+		}
+	}
+
+	return w
+}
+
+// apply returns the source with the splices made.
+func (w *writer) apply() []byte {
+	slices.SortStableFunc(w.splices, func(a, b splice) int {
+		return cmp.Or(cmp.Compare(a.from, b.from), cmp.Compare(a.to, b.to))
+	})
+
+	var (
+		out  []byte
+		prev int
+	)
+
+	for _, s := range w.splices {
+		out = append(out, w.src[prev:s.from]...)
+		out = append(out, s.text...)
+		prev = s.to
+	}
+
+	return append(out, w.src[prev:]...)
+}
+
+// mapping changes the block mapping node to value, adding and removing keys.
+func (w *writer) mapping(node ast.Node, value mapping) error {
+	values := mappingValues(node)
+	if len(values) == 0 {
+		return errRewrite
+	}
+
+	for _, mv := range values {
+		if !w.onlyIndentBefore(tokenRange(mv.Key.GetToken()).line, tokenRange(mv.Key.GetToken()).column) &&
+			mv != values[0] {
+			return errRewrite
+		}
+	}
+
+	// The keys in the document, and the ones from merge keys ("<<").
+	present := map[string]bool{}
+	merged := map[string]any{}
+
+	removed := 0
+
+	for _, mv := range values {
+		if _, ok := mv.Key.(*ast.MergeKeyNode); ok {
+			source, ok := w.value(mv.Value).(mapping)
+			if !ok {
+				return errRewrite
+			}
+
+			for _, e := range source {
+				merged[e.key] = e.value
+			}
+
+			continue
+		}
+
+		key := mv.Key.GetToken().Value
+		present[key] = true
+
+		v, ok := value.get(key)
+		if !ok {
+			if zero(w.value(mv.Value)) {
+				continue
+			}
+
+			w.remove(mv, mv == values[0])
+
+			removed++
+
+			continue
+		}
+
+		mark := len(w.splices)
+
+		err := w.set(mv.Value, v)
+
+		switch {
+		case errors.Is(err, errEmptied):
+			w.splices = w.splices[:mark]
+			w.remove(mv, mv == values[0])
+
+			removed++
+		case errors.Is(err, errRewrite):
+			w.splices = w.splices[:mark]
+			w.rewriteEntry(mv, key, v)
+		case err != nil:
+			return err
+		}
+	}
+
+	// The keys from merges can be overridden, but not removed.
+	for key, old := range merged {
+		if present[key] {
+			continue
+		}
+
+		v, ok := value.get(key)
+
+		switch {
+		case !ok && !zero(old):
+			return errRewrite
+		case ok && !equal(old, v):
+			// Added below, overriding the merged one.
+		default:
+			present[key] = true
+		}
+	}
+
+	added := 0
+
+	for i, e := range value {
+		if present[e.key] {
+			continue
+		}
+
+		w.add(values, value[:i], e)
+
+		added++
+	}
+
+	if removed == len(values) && added == 0 {
+		return errEmptied
+	}
+
+	return nil
+}
+
+// add inserts e in the mapping with values, after the last of before in it.
+func (w *writer) add(values []*ast.MappingValueNode, before mapping, e entry) {
+	first := tokenRange(values[0].Key.GetToken())
+
+	at := w.lineOffset(first.line)
+
+	// The first key might be on the line of its sequence item (eg. "- name:").
+	if !w.onlyIndentBefore(first.line, first.column) {
+		at = -1
+	}
+
+	for _, prev := range slices.Backward(before) {
+		i := slices.IndexFunc(values, func(mv *ast.MappingValueNode) bool {
+			return mv.Key.GetToken() != nil && mv.Key.GetToken().Value == prev.key
+		})
+		if i < 0 {
+			continue
+		}
+
+		if endLine, _, ok := nodeEnd(values[i]); ok {
+			at = w.lineOffset(endLine + 1)
+		}
+
+		break
+	}
+
+	if at < 0 {
+		endLine, _, _ := nodeEnd(values[len(values)-1])
+		at = w.lineOffset(endLine + 1)
+	}
+
+	w.insert(at, w.render(mapping{e}.toYAML(), first.column-1))
+}
+
+// insert inserts text at the offset at, the start of a line.
+func (w *writer) insert(at int, text string) {
+	if at > 0 && at == len(w.src) && w.src[at-1] != '\n' {
+		text = "\n" + text
+	}
+
+	w.splices = append(w.splices, splice{from: at, to: at, text: text})
+}
+
+// remove removes the key-value pair mv, with the comments right above it.
+func (w *writer) remove(mv *ast.MappingValueNode, first bool) {
+	endLine, _, _ := nodeEnd(mv)
+	startLine := w.commentsAbove(tokenRange(mv.Key.GetToken()))
+
+	// Don't leave two blank lines, or one right below the parent key.
+	if w.blankLine(endLine+1) && (first || startLine == 1 || w.blankLine(startLine-1)) {
+		endLine++
+	}
+
+	// Nor one at the end of the file.
+	if w.lineOffset(endLine+1) == len(w.src) && startLine > 1 && w.blankLine(startLine-1) {
+		startLine--
+	}
+
+	w.drop(mv.Value)
+	w.splices = append(w.splices, splice{
+		from: w.lineOffset(startLine),
+		to:   w.lineOffset(endLine + 1),
+	})
+}
+
+// blankLine returns whether line is in the source and empty.
+func (w *writer) blankLine(line int) bool {
+	from, to := w.lineOffset(line), w.lineOffset(line+1)
+
+	return from < len(w.src) && strings.TrimSpace(string(w.src[from:to])) == ""
+}
+
+// drop records the anchors in the tree of node as removed from the document.
+func (w *writer) drop(node ast.Node) {
+	ast.Walk(anchorDropper{w}, node)
+}
+
+type anchorDropper struct{ w *writer }
+
+func (d anchorDropper) Visit(node ast.Node) ast.Visitor {
+	if n, ok := node.(*ast.AnchorNode); ok && n.Name != nil {
+		d.w.dropped[n.Name.GetToken().Value] = true
+	}
+
+	return d
+}
+
+// commentsAbove returns the first line of the comments right above r, at
+// the same column, or the line of r.
+func (w *writer) commentsAbove(r sourceRange) int {
+	line := r.line
+
+	for line > 1 {
+		text := string(w.src[w.lineOffset(line-1):w.lineOffset(line)])
+		if !strings.HasPrefix(strings.TrimLeft(text, " "), "#") || len(text)-len(strings.TrimLeft(text, " ")) != r.column-1 {
+			break
+		}
+
+		line--
+	}
+
+	return line
+}
+
+// rewriteEntry writes the key-value pair mv again, as key: value, keeping the
+// comment at the end of the line of the key.
+func (w *writer) rewriteEntry(mv *ast.MappingValueNode, key string, value any) {
+	start := tokenRange(mv.Key.GetToken())
+	endLine, _, _ := nodeEnd(mv)
+
+	w.drop(mv.Value)
+
+	from := w.offset(start.line, start.column)
+	indent := start.column - 1
+	text := strings.TrimPrefix(w.render(mapping{{key, value}}.toYAML(), indent), strings.Repeat(" ", indent))
+
+	if comment := w.keyComment(mv); comment != "" {
+		first, rest, _ := strings.Cut(text, "\n")
+		text = first + " " + comment + "\n" + rest
+	}
+
+	w.splices = append(w.splices, splice{from: from, to: w.lineOffset(endLine + 1), text: text})
+}
+
+// keyComment returns the comment at the end of the line of the key of mv
+// (eg. "# the name"), if any.
+func (w *writer) keyComment(mv *ast.MappingValueNode) string {
+	key := tokenRange(mv.Key.GetToken())
+
+	// The comment is after the value, if it's on the same line.
+	column := key.endColumn
+	if r := nodeRange(mv.Value); r.line == key.line && r.endLine == key.line {
+		column = r.endColumn
+	}
+
+	rest := strings.TrimLeft(string(w.src[w.offset(key.line, column+1):w.lineOffset(key.line+1)]), " \t:")
+	if !strings.HasPrefix(rest, "#") {
+		return ""
+	}
+
+	return strings.TrimRight(rest, " \t\r\n")
+}
+
+// set changes node to value.
+func (w *writer) set(node ast.Node, value any) error {
+	switch n := node.(type) {
+	case *ast.TagNode:
+		return w.set(n.Value, value)
+	case *ast.AnchorNode:
+		if n.Name != nil {
+			w.anchorValues[n.Name.GetToken().Value] = value
+		}
+
+		return w.set(n.Value, value)
+	case *ast.AliasNode:
+		// Keep the alias if the anchor has the same value.
+		if equal(w.value(n), value) {
+			return nil
+		}
+
+		return errRewrite
+	}
+
+	if equal(w.value(node), value) {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case mapping:
+		return w.mapping(node, v)
+	case []any:
+		seq, ok := node.(*ast.SequenceNode)
+
+		switch {
+		case ok && seq.IsFlowStyle:
+			return w.flowSequence(seq, v)
+		case !ok || len(seq.Entries) != len(seq.Values):
+			return errRewrite
+		}
+
+		return w.sequence(seq, v)
+	}
+
+	return w.scalar(node, value)
+}
+
+// scalar replaces the text of the scalar node with value.
+func (w *writer) scalar(node ast.Node, value any) error {
+	switch node.(type) {
+	case *ast.StringNode, *ast.IntegerNode, *ast.FloatNode, *ast.BoolNode, *ast.InfinityNode, *ast.NanNode:
+	default:
+		return errRewrite
+	}
+
+	r := nodeRange(node)
+	if r.line == 0 || r.line != r.endLine {
+		return errRewrite
+	}
+
+	var text string
+
+	if s, ok := value.(string); ok {
+		text = formatScalar(s, node.GetToken())
+	} else {
+		out, err := yaml.Marshal(value)
+		if err != nil {
+			return errRewrite
+		}
+
+		text = strings.TrimSuffix(string(out), "\n")
+	}
+
+	if strings.Contains(text, "\n") {
+		return errRewrite
+	}
+
+	w.splices = append(w.splices, splice{
+		from: w.offset(r.line, r.column),
+		to:   w.offset(r.endLine, r.endColumn+1),
+		text: text,
+	})
+
+	return nil
+}
+
+// sequence changes the block sequence seq to items, keeping the items that
+// are the same.
+func (w *writer) sequence(seq *ast.SequenceNode, items []any) error {
+	old := make([]any, len(seq.Values))
+	for i, node := range seq.Values {
+		old[i] = w.value(node)
+	}
+
+	for _, entry := range seq.Entries {
+		if r := tokenRange(entry.Start); !w.onlyIndentBefore(r.line, r.column) {
+			return errRewrite
+		}
+	}
+
+	column := tokenRange(seq.Entries[0].Start).column
+
+	// After the last item kept or changed, or before the first one.
+	at := w.lineOffset(w.commentsAbove(tokenRange(seq.Entries[0].Start)))
+
+	i, j := 0, 0
+	for _, match := range append(lcs(old, items), [2]int{len(old), len(items)}) {
+		// Change the items in between, then remove or add the ones left.
+		for ; i < match[0] && j < match[1]; i, j = i+1, j+1 {
+			mark := len(w.splices)
+			if err := w.set(seq.Values[i], items[j]); err != nil {
+				w.splices = w.splices[:mark]
+				w.rewriteItem(seq.Entries[i], items[j])
+			}
+
+			at = w.itemEnd(seq.Entries[i])
+		}
+
+		for ; i < match[0]; i++ {
+			w.splices = append(w.splices, splice{
+				from: w.lineOffset(w.commentsAbove(tokenRange(seq.Entries[i].Start))),
+				to:   w.itemEnd(seq.Entries[i]),
+			})
+		}
+
+		for ; j < match[1]; j++ {
+			w.insert(at, w.render([]any{toYAML(items[j])}, column-1))
+		}
+
+		if i < len(old) {
+			at = w.itemEnd(seq.Entries[i])
+		}
+
+		i, j = i+1, j+1
+	}
+
+	return nil
+}
+
+// flowSequence changes the flow sequence of scalars seq, on a single line, to
+// items, keeping the text of the items that are the same and the spacing.
+func (w *writer) flowSequence(seq *ast.SequenceNode, items []any) error {
+	r := nodeRange(seq)
+	if r.line == 0 || r.line != r.endLine {
+		return errRewrite
+	}
+
+	old := make([]any, len(seq.Values))
+	texts := make([]string, len(seq.Values))
+	ranges := make([]sourceRange, len(seq.Values))
+
+	for i, node := range seq.Values {
+		switch node.(type) {
+		case *ast.StringNode, *ast.IntegerNode, *ast.FloatNode, *ast.BoolNode, *ast.InfinityNode, *ast.NanNode:
+		default:
+			return errRewrite
+		}
+
+		ranges[i] = nodeRange(node)
+		if ranges[i].line != r.line || ranges[i].endLine != r.line {
+			return errRewrite
+		}
+
+		old[i] = w.value(node)
+		texts[i] = string(w.src[w.offset(r.line, ranges[i].column):w.offset(r.line, ranges[i].endColumn+1)])
+	}
+
+	var out []string
+
+	i, j := 0, 0
+	for _, match := range append(lcs(old, items), [2]int{len(old), len(items)}) {
+		for ; j < match[1]; i, j = i+1, j+1 {
+			var tok *token.Token
+			if i < match[0] {
+				tok = seq.Values[i].GetToken()
+			}
+
+			text, ok := flowScalar(items[j], tok)
+			if !ok {
+				return errRewrite
+			}
+
+			out = append(out, text)
+		}
+
+		if j < len(items) {
+			out = append(out, texts[match[0]])
+		}
+
+		i, j = match[0]+1, j+1
+	}
+
+	// Keep the separator and the spaces inside the brackets (eg. "[ a, b ]").
+	from, to := w.offset(r.line, r.column+1), w.offset(r.line, r.endColumn)
+	sep, text := ", ", ""
+
+	if len(seq.Values) > 1 {
+		sep = string(w.src[w.offset(r.line, ranges[0].endColumn+1):w.offset(r.line, ranges[1].column)])
+	}
+
+	if len(out) > 0 {
+		text = strings.Join(out, sep)
+
+		if len(seq.Values) > 0 {
+			text = string(w.src[from:w.offset(r.line, ranges[0].column)]) + text +
+				string(w.src[w.offset(r.line, ranges[len(ranges)-1].endColumn+1):to])
+		}
+	}
+
+	w.splices = append(w.splices, splice{from: from, to: to, text: text})
+
+	return nil
+}
+
+// flowScalar returns the text of value as an item of a flow sequence, in the
+// style of old, if any, and false if value is not a scalar.
+func flowScalar(value any, old *token.Token) (string, bool) {
+	switch value.(type) {
+	case mapping, []any, nil:
+		return "", false
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		out, err := yaml.Marshal(value)
+
+		return strings.TrimSuffix(string(out), "\n"), err == nil
+	}
+
+	text := formatScalar(s, old)
+
+	// The flow indicators must be quoted inside flow sequences.
+	if !strings.HasPrefix(text, `"`) && !strings.HasPrefix(text, "'") && strings.ContainsAny(text, ",[]{}") {
+		text = fmt.Sprintf("%q", s)
+	}
+
+	return text, !strings.Contains(text, "\n")
+}
+
+// itemEnd returns the offset of the line after the sequence item.
+func (w *writer) itemEnd(item *ast.SequenceEntryNode) int {
+	endLine, _, ok := nodeEnd(item.Value)
+	if !ok || endLine < tokenRange(item.Start).line {
+		endLine = tokenRange(item.Start).line
+	}
+
+	return w.lineOffset(endLine + 1)
+}
+
+// rewriteItem writes the sequence item again, with value.
+func (w *writer) rewriteItem(item *ast.SequenceEntryNode, value any) {
+	start := tokenRange(item.Start)
+
+	w.drop(item.Value)
+	w.splices = append(w.splices, splice{
+		from: w.lineOffset(start.line),
+		to:   w.itemEnd(item),
+		text: w.render([]any{toYAML(value)}, start.column-1),
+	})
+}
+
+// lcs returns the indexes of the items of a and b in their longest common
+// subsequence.
+func lcs(a []any, b []any) [][2]int {
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if equal(a[i], b[j]) {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	var matches [][2]int
+
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case equal(a[i], b[j]):
+			matches = append(matches, [2]int{i, j})
+			i, j = i+1, j+1
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+
+	return matches
+}
+
+// value returns the value of node in the document, resolving aliases and
+// merge keys.
+func (w *writer) value(node ast.Node) any {
+	switch n := node.(type) {
+	case nil, *ast.NullNode:
+		return nil
+	case *ast.TagNode:
+		return w.value(n.Value)
+	case *ast.AnchorNode:
+		return w.value(n.Value)
+	case *ast.AliasNode:
+		// The value of the anchor once written.
+		name := n.Value.GetToken().Value
+
+		if w.dropped[name] {
+			return stale{}
+		}
+
+		if v, ok := w.anchorValues[name]; ok {
+			return v
+		}
+
+		return w.value(w.anchors[name])
+	case *ast.MappingNode, *ast.MappingValueNode:
+		var values []*ast.MappingValueNode
+
+		switch n := n.(type) {
+		case *ast.MappingNode:
+			values = n.Values
+		case *ast.MappingValueNode:
+			values = []*ast.MappingValueNode{n}
+		}
+
+		var m mapping
+
+		for _, mv := range values {
+			if _, ok := mv.Key.(*ast.MergeKeyNode); ok {
+				sources := []any{w.value(mv.Value)}
+				if list, ok := sources[0].([]any); ok {
+					sources = list
+				}
+
+				for _, source := range sources {
+					source, _ := source.(mapping)
+					for _, e := range source {
+						if _, ok := m.get(e.key); !ok {
+							m = append(m, e)
+						}
+					}
+				}
+
+				continue
+			}
+
+			key := mv.Key.GetToken().Value
+			m = slices.DeleteFunc(m, func(e entry) bool { return e.key == key })
+			m = append(m, entry{key, w.value(mv.Value)})
+		}
+
+		return m
+	case *ast.SequenceNode:
+		items := make([]any, 0, len(n.Values))
+		for _, item := range n.Values {
+			items = append(items, w.value(item))
+		}
+
+		return items
+	case *ast.LiteralNode:
+		var s string
+		if err := yaml.NodeToValue(n, &s); err != nil {
+			return n.GetValue()
+		}
+
+		return s
+	case ast.ScalarNode:
+		return scalar{value: n.GetValue(), text: n.GetToken().Value}
+	}
+
+	return nil
+}
+
+// render returns v in YAML, indented by indent spaces.
+func (w *writer) render(v any, indent int) string {
+	out, err := yaml.MarshalWithOptions(v, yaml.Indent(w.indent), yaml.IndentSequence(w.indentSequence))
+	if err != nil {
+		return ""
+	}
+
+	// Top level sequences are indented too with IndentSequence.
+	text := string(out)
+	dedent := len(text) - len(strings.TrimLeft(text, " "))
+
+	lines := strings.SplitAfter(text, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			lines[i] = strings.Repeat(" ", indent) + line[min(dedent, len(line)-len(strings.TrimLeft(line, " "))):]
+		}
+	}
+
+	return strings.Join(lines, "")
+}
+
+// canonicalOrder returns src with the keys of its mappings ordered as the
+// fields of t.
+func canonicalOrder(src []byte, t reflect.Type) ([]byte, error) {
+	// Each pass orders one mapping, until they're all in order.
+	for {
+		file, err := parser.ParseBytes(src, 0)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse publiccode.yml: %w", err)
+		}
+
+		if len(file.Docs) == 0 || file.Docs[0].Body == nil {
+			return src, nil
+		}
+
+		e := editor{src: src, root: file.Docs[0].Body}
+
+		ordered, ok := e.reorder(file.Docs[0].Body, t)
+		if !ok {
+			return src, nil
+		}
+
+		src = ordered
+	}
+}
+
+// reorder returns the source with the first mapping not in the order of t
+// in the tree of node ordered, and false if they are all in order already.
+func (e *editor) reorder(node ast.Node, t reflect.Type) ([]byte, bool) {
+	for t != nil && (t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+	}
+
+	switch n := node.(type) {
+	case *ast.TagNode:
+		return e.reorder(n.Value, t)
+	case *ast.AnchorNode:
+		return e.reorder(n.Value, t)
+	case *ast.SequenceNode:
+		for _, item := range n.Values {
+			if out, ok := e.reorder(item, t); ok {
+				return out, true
+			}
+		}
+	case *ast.MappingNode, *ast.MappingValueNode:
+		values := mappingValues(n)
+
+		if t != nil && t.Kind() == reflect.Struct {
+			if out, ok := e.orderMapping(values, fieldOrder(t)); ok {
+				return out, true
+			}
+		}
+
+		for _, mv := range values {
+			var child reflect.Type
+
+			if t != nil {
+				switch t.Kind() { //nolint:exhaustive // only the collections have children
+				case reflect.Struct:
+					if i := slices.Index(fieldOrder(t), mv.Key.GetToken().Value); i >= 0 {
+						child = fieldByName(t, mv.Key.GetToken().Value)
+					}
+				case reflect.Map:
+					child = t.Elem()
+				}
+			}
+
+			if out, ok := e.reorder(mv.Value, child); ok {
+				return out, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// fieldOrder returns the keys of the struct t, in order.
+func fieldOrder(t reflect.Type) []string {
+	var keys []string
+
+	for i := range t.NumField() {
+		if name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ","); name != "" && name != "-" {
+			keys = append(keys, name)
+		}
+	}
+
+	return keys
+}
+
+// fieldByName returns the type of the field of t with the yaml key.
+func fieldByName(t reflect.Type, key string) reflect.Type {
+	for i := range t.NumField() {
+		if name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ","); name == key {
+			return t.Field(i).Type
+		}
+	}
+
+	return nil
+}
+
+// orderMapping returns the source with the key-value pairs in values ordered
+// as keys, and false if they are in order already or can't be moved. Merge
+// keys go first and unknown keys last.
+func (e *editor) orderMapping(values []*ast.MappingValueNode, keys []string) ([]byte, bool) {
+	rank := func(mv *ast.MappingValueNode) int {
+		if _, ok := mv.Key.(*ast.MergeKeyNode); ok {
+			return -1
+		}
+
+		if i := slices.Index(keys, mv.Key.GetToken().Value); i >= 0 {
+			return i
+		}
+
+		return len(keys)
+	}
+
+	ordered := slices.Clone(values)
+	slices.SortStableFunc(ordered, func(a, b *ast.MappingValueNode) int { return cmp.Compare(rank(a), rank(b)) })
+
+	if slices.Equal(ordered, values) {
+		return nil, false
+	}
+
+	// Each pair is moved with the comments right above it, the text in
+	// between (eg. blank lines) stays in place.
+	type block struct{ from, to int }
+
+	w := writer{editor: *e}
+	blocks := make(map[*ast.MappingValueNode]block, len(values))
+
+	first := tokenRange(values[0].Key.GetToken())
+	prefix := ""
+
+	for i, mv := range values {
+		r := tokenRange(mv.Key.GetToken())
+
+		endLine, _, ok := nodeEnd(mv)
+		if !ok {
+			return nil, false
+		}
+
+		from := w.lineOffset(w.commentsAbove(r))
+
+		// The first key might be on the line of its sequence item (eg.
+		// "- name:"): move it as if the item's "- " was indentation.
+		if !w.onlyIndentBefore(r.line, r.column) {
+			if i > 0 || r.column != first.column {
+				return nil, false
+			}
+
+			from = w.lineOffset(r.line)
+			prefix = string(e.src[from:w.offset(r.line, r.column)])
+		}
+
+		if r.column != first.column || (i > 0 && from < blocks[values[i-1]].to) {
+			return nil, false
+		}
+
+		blocks[mv] = block{from, w.lineOffset(endLine + 1)}
+	}
+
+	src := e.src
+	if prefix != "" {
+		src = slices.Concat(src[:blocks[values[0]].from], []byte(strings.Repeat(" ", len(prefix))),
+			src[blocks[values[0]].from+len(prefix):])
+	}
+
+	var out []byte
+
+	prev := 0
+
+	for i, mv := range values {
+		b := blocks[mv]
+		moved := blocks[ordered[i]]
+
+		text := src[moved.from:moved.to]
+		if i == 0 && prefix != "" {
+			text = slices.Concat([]byte(prefix), text[len(prefix):])
+		}
+
+		out = append(out, src[prev:b.from]...)
+		out = append(out, text...)
+		prev = b.to
+	}
+
+	return append(out, src[prev:]...), true
+}
@@ -0,0 +1,319 @@
+package publiccode
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteYAMLUnchanged(t *testing.T) {
+	files, _ := filepath.Glob("testdata/v0/valid*/*.yml")
+	more, _ := filepath.Glob("testdata/v0/valid*/no-network/*.yml")
+	files = append(files, more...)
+
+	p, err := NewParser(ParserConfig{DisableExternalChecks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range files {
+		// The Parser sets organisation from IT.riuso.codiceIPA.
+		if filepath.Base(file) == "valid_with_IT_riuso_codiceIPA.yml" {
+			continue
+		}
+
+		t.Run(file, func(t *testing.T) {
+			src, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pc, err := p.Parse(file)
+			if hasErrors(err) {
+				t.Fatalf("unexpected errors: %v", err)
+			}
+
+			out, err := WriteYAML(src, pc, WriteOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(out) != string(src) {
+				t.Errorf("file changed:\n%s", out)
+			}
+		})
+	}
+}
+
+const writeYAMLSrc = `# publiccode.yml of Medusa
+publiccodeYmlVersion: "0.2"
+
+name: Medusa # the name
+url: "https://github.com/italia/developers.italia.it.git"
+releaseDate: 2017-04-15
+
+platforms: [web]
+
+description:
+  en:
+    # Deprecated
+    genericName: Medusa
+    shortDescription: Short
+    features:
+      - One
+      - Two
+      - Three
+
+maintenance:
+  type: community
+  contacts:
+    - name: Francesco Rossi
+    - name: Dario Bianchi
+`
+
+func writeYAMLTestcase(t *testing.T) PublicCodeV0 {
+	t.Helper()
+
+	p, err := NewParser(ParserConfig{DisableExternalChecks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pc, _ := p.ParseStream(strings.NewReader(writeYAMLSrc))
+
+	v0, ok := pc.(PublicCodeV0)
+	if !ok {
+		t.Fatalf("unexpected type %T", pc)
+	}
+
+	return v0
+}
+
+func TestWriteYAML(t *testing.T) {
+	v0 := writeYAMLTestcase(t)
+
+	v0.PubliccodeYamlVersion = "0"
+	v0.Name = "Medusa 2"
+	v0.Platforms = append(v0.Platforms, "android")
+	v0.Description["en"] = DescV0{ShortDescription: "Short", Features: &[]string{"One", "Three", "Four"}}
+
+	landingURL, _ := toURL("https://example.org")
+	v0.LandingURL = (*URL)(landingURL)
+
+	out, err := WriteYAML([]byte(writeYAMLSrc), v0, WriteOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `# publiccode.yml of Medusa
+publiccodeYmlVersion: "0"
+
+name: Medusa 2 # the name
+url: "https://github.com/italia/developers.italia.it.git"
+landingURL: https://example.org
+releaseDate: 2017-04-15
+
+platforms: [web, android]
+
+description:
+  en:
+    shortDescription: Short
+    features:
+      - One
+      - Three
+      - Four
+
+maintenance:
+  type: community
+  contacts:
+    - name: Francesco Rossi
+    - name: Dario Bianchi
+`
+	if string(out) != expected {
+		t.Errorf("wrong output:\n%s\n- instead of:\n%s", out, expected)
+	}
+}
+
+func TestWriteYAMLComments(t *testing.T) {
+	src := `publiccodeYmlVersion: "0"
+name: Medusa
+platforms: [ web, "linux" ] # plats
+categories: it-development # cats
+`
+	p, err := NewParser(ParserConfig{DisableExternalChecks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pc, _ := p.ParseStream(strings.NewReader(src))
+
+	v0, ok := pc.(PublicCodeV0)
+	if !ok {
+		t.Fatalf("unexpected type %T", pc)
+	}
+
+	v0.Platforms = []string{"android", "linux", "a, b"}
+	v0.Categories = &[]string{"it-development", "cloud-management"}
+
+	out, err := WriteYAML([]byte(src), v0, WriteOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `publiccodeYmlVersion: "0"
+name: Medusa
+platforms: [ android, "linux", "a, b" ] # plats
+categories: # cats
+  - it-development
+  - cloud-management
+`
+	if string(out) != expected {
+		t.Errorf("wrong output:\n%s\n- instead of:\n%s", out, expected)
+	}
+}
+
+func TestWriteYAMLAnchors(t *testing.T) {
+	src := `publiccodeYmlVersion: "0"
+maintenance:
+  type: community
+  contacts:
+    - &contact
+      name: Francesco Rossi # the maintainer
+    - name: Dario Bianchi
+  contractors:
+    - name: Fornitore
+      until: "2019-01-01"
+`
+	p, err := NewParser(ParserConfig{DisableExternalChecks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pc, _ := p.ParseStream(strings.NewReader(src + "x-owner: *contact\n"))
+	v0 := pc.(PublicCodeV0)
+
+	email := "dario.bianchi@example.org"
+	(*v0.Maintenance.Contacts)[1].Email = &email
+
+	out, err := WriteYAML([]byte(src), v0, WriteOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := strings.Replace(src, "    - name: Dario Bianchi\n",
+		"    - name: Dario Bianchi\n      email: dario.bianchi@example.org\n", 1)
+	if string(out) != expected {
+		t.Errorf("wrong output:\n%s\n- instead of:\n%s", out, expected)
+	}
+}
+
+func TestWriteYAMLCanonical(t *testing.T) {
+	src := `name: Medusa
+# The version
+publiccodeYmlVersion: "0"
+
+maintenance:
+  contacts:
+    - email: francesco.rossi@example.org
+      name: Francesco Rossi # first
+  type: community
+
+x-custom: true
+
+legal:
+  license: MIT
+`
+	p, err := NewParser(ParserConfig{DisableExternalChecks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pc, _ := p.ParseStream(strings.NewReader(src))
+
+	out, err := WriteYAML([]byte(src), pc, WriteOptions{Canonical: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `# The version
+publiccodeYmlVersion: "0"
+name: Medusa
+
+legal:
+  license: MIT
+
+maintenance:
+  type: community
+  contacts:
+    - name: Francesco Rossi # first
+      email: francesco.rossi@example.org
+`
+	if string(out) != expected {
+		t.Errorf("wrong output:\n%s\n- instead of:\n%s", out, expected)
+	}
+}
+
+func TestWriteYAMLEmpty(t *testing.T) {
+	v0 := writeYAMLTestcase(t)
+	v0.ReleaseDate = nil
+
+	out, err := WriteYAML(nil, v0, WriteOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Contains(out, []byte("null")) || !bytes.HasPrefix(out, []byte("publiccodeYmlVersion: \"0.2\"\n")) {
+		t.Errorf("wrong output:\n%s", out)
+	}
+
+	p, err := NewParser(ParserConfig{DisableExternalChecks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	written, _ := p.ParseStream(bytes.NewReader(out))
+	if written == nil {
+		t.Fatalf("can't parse the output:\n%s", out)
+	}
+
+	if again, _ := WriteYAML(out, written, WriteOptions{}); !bytes.Equal(again, out) {
+		t.Errorf("file changed writing it again:\n%s", again)
+	}
+}
+
+func TestWriteYAMLUpgrade(t *testing.T) {
+	file := "testdata/v0/valid_with_warnings/valid_with_IT_riuso_codiceIPA.yml"
+
+	src, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := NewParser(ParserConfig{DisableExternalChecks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pc, _ := p.Parse(file)
+	upgraded, _ := Upgrade(pc)
+
+	out, err := WriteYAML(src, upgraded, WriteOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := strings.NewReplacer(
+		"releaseDate: 2017-04-15\n",
+		"releaseDate: 2017-04-15\norganisation:\n  uri: urn:x-italian-pa:pcm\n",
+		"softwareType: \"standalone/other\"\n",
+		"softwareType: \"standalone/other\"\nsupports:\n"+
+			"  - id: alias:spid\n  - id: alias:pagopa\n  - id: alias:cie\n  - id: alias:anpr\n",
+	).Replace(string(src[:bytes.Index(src, []byte("\nIT:\n"))+1]))
+	expected = strings.TrimSuffix(expected, "\n\n") + "\n"
+
+	if string(out) != expected {
+		t.Errorf("wrong output:\n%s\n- instead of:\n%s", out, expected)
+	}
+}